@@ -0,0 +1,20 @@
+-- movies.abuse_flags definition
+
+CREATE TABLE `abuse_flags` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `event_type` enum('view','vote','registration') NOT NULL,
+  `event_id` int NOT NULL,
+  `user_id` int DEFAULT NULL,
+  `ip_address` varchar(45) DEFAULT NULL,
+  `score` int NOT NULL DEFAULT '0',
+  `reason` varchar(255) NOT NULL,
+  `status` enum('open','confirmed','dismissed') NOT NULL DEFAULT 'open',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `reviewed_by` int DEFAULT NULL,
+  `reviewed_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `status_idx` (`status`),
+  KEY `event_idx` (`event_type`,`event_id`),
+  CONSTRAINT `fk_abuse_flags_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_abuse_flags_reviewer` FOREIGN KEY (`reviewed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,20 @@
+-- movies.audience_ballots definition
+-- One audience-award ballot (score 1-5) per user per movie, cast only by attendees.
+-- source is 'screening' with the checked-in ticket that proves attendance, or 'online' after a verified full watch.
+
+CREATE TABLE `audience_ballots` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `movie_id` int NOT NULL,
+  `score` tinyint NOT NULL,
+  `source` enum('screening','online') NOT NULL,
+  `ticket_id` int DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `user_movie` (`user_id`,`movie_id`),
+  KEY `movie_id` (`movie_id`),
+  KEY `ticket_id` (`ticket_id`),
+  CONSTRAINT `fk_audience_ballots_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_audience_ballots_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_audience_ballots_ticket` FOREIGN KEY (`ticket_id`) REFERENCES `tickets` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,15 @@
+-- movies.award_categories definition
+-- Awards of an edition, e.g. Best Film or the Audience Award. Audience awards are computed from votes.
+-- Winners stay hidden until published_at has passed.
+
+CREATE TABLE `award_categories` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `edition_id` int NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `description` text,
+  `kind` enum('jury','audience') NOT NULL DEFAULT 'jury',
+  `published_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `edition_name` (`edition_id`,`name`),
+  CONSTRAINT `fk_award_categories_edition` FOREIGN KEY (`edition_id`) REFERENCES `festival_editions` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,14 @@
+-- movies.award_nominations definition
+-- Movies nominated in an award category; is_winner marks the winners, several for ex aequo awards.
+
+CREATE TABLE `award_nominations` (
+  `category_id` int NOT NULL,
+  `movie_id` int NOT NULL,
+  `citation` varchar(255) NOT NULL DEFAULT '',
+  `is_winner` tinyint(1) NOT NULL DEFAULT '0',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`category_id`,`movie_id`),
+  KEY `movie_id` (`movie_id`),
+  CONSTRAINT `fk_award_nominations_category` FOREIGN KEY (`category_id`) REFERENCES `award_categories` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_award_nominations_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,16 @@
+-- movies.festival_editions definition
+-- One row per yearly edition of the festival; movies and statistics are scoped to an edition.
+-- blind_review hides the identity of filmmakers from submission reviewers.
+
+CREATE TABLE `festival_editions` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `year` int NOT NULL,
+  `theme` varchar(255) NOT NULL DEFAULT '',
+  `starts_on` date NOT NULL,
+  `ends_on` date NOT NULL,
+  `blind_review` tinyint(1) NOT NULL DEFAULT '0',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `year` (`year`),
+  KEY `starts_on_idx` (`starts_on`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
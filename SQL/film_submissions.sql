@@ -0,0 +1,31 @@
+-- movies.film_submissions definition
+-- Films submitted by filmmakers for an edition. Status moves from received through in_review to selected or rejected.
+-- movie_id is set when a selected submission is converted into a catalogue movie.
+
+CREATE TABLE `film_submissions` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `edition_id` int NOT NULL,
+  `section_id` int DEFAULT NULL,
+  `title` varchar(255) NOT NULL,
+  `description` text,
+  `duration` varchar(10) DEFAULT NULL,
+  `artist` text,
+  `genre_id` int DEFAULT NULL,
+  `watch_url` text NOT NULL,
+  `status` enum('received','in_review','selected','rejected') NOT NULL DEFAULT 'received',
+  `movie_id` int DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `movie_id` (`movie_id`),
+  KEY `user_idx` (`user_id`,`created_at`),
+  KEY `edition_status_idx` (`edition_id`,`status`),
+  KEY `section_id` (`section_id`),
+  KEY `genre_id` (`genre_id`),
+  CONSTRAINT `fk_film_submissions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_film_submissions_edition` FOREIGN KEY (`edition_id`) REFERENCES `festival_editions` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_film_submissions_section` FOREIGN KEY (`section_id`) REFERENCES `programme_sections` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_film_submissions_genre` FOREIGN KEY (`genre_id`) REFERENCES `genres` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_film_submissions_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
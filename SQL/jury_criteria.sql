@@ -0,0 +1,12 @@
+-- movies.jury_criteria definition
+-- Criteria a panel scores each movie on, e.g. Direction or Screenplay. weight sets their share of the ranking.
+
+CREATE TABLE `jury_criteria` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `panel_id` int NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `weight` int NOT NULL DEFAULT '1',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `panel_name` (`panel_id`,`name`),
+  CONSTRAINT `fk_jury_criteria_panel` FOREIGN KEY (`panel_id`) REFERENCES `jury_panels` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,13 @@
+-- movies.jury_members definition
+-- Jurors of a panel; at most one member per panel is the president.
+
+CREATE TABLE `jury_members` (
+  `panel_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `is_president` tinyint(1) NOT NULL DEFAULT '0',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`panel_id`,`user_id`),
+  KEY `user_id` (`user_id`),
+  CONSTRAINT `fk_jury_members_panel` FOREIGN KEY (`panel_id`) REFERENCES `jury_panels` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_jury_members_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
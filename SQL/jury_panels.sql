@@ -0,0 +1,16 @@
+-- movies.jury_panels definition
+-- One jury per programme section. Once locked_at is set the jury's scores are final.
+
+CREATE TABLE `jury_panels` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `section_id` int NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `locked_at` timestamp NULL DEFAULT NULL,
+  `locked_by` int DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `section_id` (`section_id`),
+  KEY `locked_by` (`locked_by`),
+  CONSTRAINT `fk_jury_panels_section` FOREIGN KEY (`section_id`) REFERENCES `programme_sections` (`id`),
+  CONSTRAINT `fk_jury_panels_locked_by` FOREIGN KEY (`locked_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,19 @@
+-- movies.jury_scores definition
+-- A juror's private score (1-10) for one movie on one criterion.
+-- Scores are removed with the juror's membership or the criterion.
+
+CREATE TABLE `jury_scores` (
+  `panel_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `movie_id` int NOT NULL,
+  `criterion_id` int NOT NULL,
+  `score` tinyint NOT NULL,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`,`movie_id`,`criterion_id`),
+  KEY `panel_movie_idx` (`panel_id`,`movie_id`),
+  KEY `criterion_id` (`criterion_id`),
+  KEY `movie_id` (`movie_id`),
+  CONSTRAINT `fk_jury_scores_member` FOREIGN KEY (`panel_id`, `user_id`) REFERENCES `jury_members` (`panel_id`, `user_id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_jury_scores_criterion` FOREIGN KEY (`criterion_id`) REFERENCES `jury_criteria` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_jury_scores_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
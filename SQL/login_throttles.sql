@@ -0,0 +1,13 @@
+-- movies.login_throttles definition
+-- Failed login attempts per account (lowercased email) and per client IP.
+-- A subject is locked until locked_until once it reaches the attempt limit of its scope.
+
+CREATE TABLE `login_throttles` (
+  `scope` enum('account','ip') NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `failed_count` int NOT NULL DEFAULT '0',
+  `last_failed_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `locked_until` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`scope`,`subject`),
+  KEY `locked_until_idx` (`locked_until`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,15 @@
+-- movies.movie_availability definition
+-- Online availability rules of a movie; movies without a row are available everywhere at any time.
+-- countries is a comma-separated list of ISO 3166-1 alpha-2 codes, empty for every country.
+-- New playback sessions lock the row while counting concurrent viewers against max_viewers.
+
+CREATE TABLE `movie_availability` (
+  `movie_id` int NOT NULL,
+  `available_from` datetime DEFAULT NULL,
+  `available_until` datetime DEFAULT NULL,
+  `countries` varchar(1000) NOT NULL DEFAULT '',
+  `max_viewers` int DEFAULT NULL,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`movie_id`),
+  CONSTRAINT `fk_movie_availability_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,14 @@
+-- movies.movie_similarities definition
+-- Item-item similarities produced by "movies recommend train".
+
+CREATE TABLE `movie_similarities` (
+  `movie_id` int NOT NULL,
+  `similar_movie_id` int NOT NULL,
+  `score` double NOT NULL,
+  `trained_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`movie_id`,`similar_movie_id`),
+  KEY `similar_movie_idx` (`similar_movie_id`),
+  KEY `trained_at_idx` (`trained_at`),
+  CONSTRAINT `fk_movie_similarities_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_movie_similarities_similar` FOREIGN KEY (`similar_movie_id`) REFERENCES `movies` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -1,15 +1,31 @@
 -- movies.movie_views definition
+-- Each row is one playback session; a user may have many sessions for the same movie.
+-- Anonymous sessions have no user_id and are identified by the signed visitor_id instead.
+-- source is 'screening' for seats checked in at a physical screening, one row per seat: the ticket owner's
+-- seat has their user_id, every other seat a visitor_id of its own ("ticket-<ticket id>-seat-<n>").
 
 CREATE TABLE `movie_views` (
   `id` int NOT NULL AUTO_INCREMENT,
+  `session_id` varchar(64) NOT NULL,
   `movie_id` int NOT NULL,
-  `user_id` int NOT NULL,
+  `user_id` int DEFAULT NULL,
+  `visitor_id` varchar(64) DEFAULT NULL,
   `viewed_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `last_heartbeat_at` timestamp NULL DEFAULT NULL,
+  `ended_at` timestamp NULL DEFAULT NULL,
   `duration` int NOT NULL DEFAULT '0',
+  `ip_address` varchar(45) DEFAULT NULL,
+  `flagged` tinyint(1) NOT NULL DEFAULT '0',
+  `source` enum('online','screening') NOT NULL DEFAULT 'online',
+  `ticket_id` int DEFAULT NULL,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `unique_view` (`movie_id`,`user_id`),
+  UNIQUE KEY `session_id` (`session_id`),
+  KEY `movie_user_idx` (`movie_id`,`user_id`),
   KEY `movie_idx` (`movie_id`),
   KEY `user_idx` (`user_id`),
+  KEY `movie_visitor_idx` (`movie_id`,`visitor_id`,`viewed_at`),
+  KEY `ip_viewed_idx` (`ip_address`,`viewed_at`),
+  KEY `ticket_idx` (`ticket_id`),
   CONSTRAINT `fk_movie_views_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`) ON DELETE CASCADE,
   CONSTRAINT `fk_movie_views_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
\ No newline at end of file
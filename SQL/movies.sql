@@ -8,7 +8,13 @@ CREATE TABLE `movies` (
   `artist` text,
   `genre_id` int DEFAULT NULL,
   `watch_url` text NOT NULL,
+  `edition_id` int DEFAULT NULL,
+  `section_id` int DEFAULT NULL,
   PRIMARY KEY (`id`),
   KEY `genre_id` (`genre_id`),
-  CONSTRAINT `movies_ibfk_1` FOREIGN KEY (`genre_id`) REFERENCES `genres` (`id`) ON DELETE SET NULL
+  KEY `edition_id` (`edition_id`),
+  KEY `section_id` (`section_id`),
+  CONSTRAINT `movies_ibfk_1` FOREIGN KEY (`genre_id`) REFERENCES `genres` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_movies_edition` FOREIGN KEY (`edition_id`) REFERENCES `festival_editions` (`id`),
+  CONSTRAINT `fk_movies_section` FOREIGN KEY (`section_id`) REFERENCES `programme_sections` (`id`) ON DELETE SET NULL
 ) ENGINE=InnoDB AUTO_INCREMENT=16 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
\ No newline at end of file
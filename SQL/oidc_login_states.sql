@@ -0,0 +1,11 @@
+-- movies.oidc_login_states definition
+-- Pending OIDC logins: the state sent to the provider with the nonce and PKCE verifier needed on callback.
+
+CREATE TABLE `oidc_login_states` (
+  `state` varchar(64) NOT NULL,
+  `nonce` varchar(64) NOT NULL,
+  `code_verifier` varchar(128) NOT NULL,
+  `expires_at` timestamp NOT NULL,
+  PRIMARY KEY (`state`),
+  KEY `expires_idx` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,12 @@
+-- movies.programme_sections definition
+-- Sections of an edition's programme, e.g. Competition, Shorts or Retrospective.
+
+CREATE TABLE `programme_sections` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `edition_id` int NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `description` text,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `edition_name` (`edition_id`,`name`),
+  CONSTRAINT `fk_programme_sections_edition` FOREIGN KEY (`edition_id`) REFERENCES `festival_editions` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
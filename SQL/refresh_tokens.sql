@@ -0,0 +1,20 @@
+-- movies.refresh_tokens definition
+-- Only the SHA-256 hash of each refresh token is stored. Tokens rotated from the same login share a family_id.
+-- two_factor records whether that login passed the TOTP step, so refreshed access tokens keep the flag.
+
+CREATE TABLE `refresh_tokens` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `two_factor` tinyint(1) NOT NULL DEFAULT '0',
+  `expires_at` timestamp NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `revoked_at` timestamp NULL DEFAULT NULL,
+  `replaced_by_id` int DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `token_hash` (`token_hash`),
+  KEY `family_idx` (`family_id`),
+  KEY `user_idx` (`user_id`),
+  CONSTRAINT `fk_refresh_tokens_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,10 @@
+-- movies.revoked_tokens definition
+-- Access token IDs (jti) rejected by AuthMiddleware until the token would have expired anyway.
+
+CREATE TABLE `revoked_tokens` (
+  `jti` varchar(64) NOT NULL,
+  `expires_at` timestamp NOT NULL,
+  `revoked_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`jti`),
+  KEY `expires_idx` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,76 @@
+-- movies.roles, movies.permissions and movies.role_permissions definitions
+-- Routes check permissions; roles are named bundles of permissions assigned to users.
+
+CREATE TABLE `roles` (
+  `name` varchar(50) NOT NULL,
+  `description` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `permissions` (
+  `name` varchar(50) NOT NULL,
+  `description` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `role_permissions` (
+  `role` varchar(50) NOT NULL,
+  `permission` varchar(50) NOT NULL,
+  PRIMARY KEY (`role`,`permission`),
+  KEY `permission_idx` (`permission`),
+  CONSTRAINT `fk_role_permissions_role` FOREIGN KEY (`role`) REFERENCES `roles` (`name`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_role_permissions_permission` FOREIGN KEY (`permission`) REFERENCES `permissions` (`name`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+INSERT INTO `roles` (`name`, `description`) VALUES
+  ('admin', 'Full access'),
+  ('user', 'Festival audience member'),
+  ('curator', 'Edits the movie catalogue'),
+  ('analyst', 'Reads statistics'),
+  ('moderator', 'Reviews abuse reports and user accounts'),
+  ('volunteer', 'Scans tickets at the venue door'),
+  ('juror', 'Scores the movies of a jury panel'),
+  ('filmmaker', 'Submits films to the festival'),
+  ('programmer', 'Reviews the film submissions assigned to them');
+
+INSERT INTO `permissions` (`name`, `description`) VALUES
+  ('movies:write', 'Create and update movies'),
+  ('stats:read', 'Read viewing and voting statistics'),
+  ('users:read', 'List user accounts'),
+  ('users:manage', 'Change roles and disable accounts'),
+  ('abuse:review', 'Review abuse flags'),
+  ('festival:manage', 'Manage festival editions and programme sections'),
+  ('screenings:manage', 'Manage venues and the screening schedule'),
+  ('tickets:checkin', 'Check in tickets at the door and follow attendance'),
+  ('jury:manage', 'Set up jury panels and read every ranking'),
+  ('jury:score', 'Score movies as a member of a jury panel'),
+  ('awards:manage', 'Manage award categories, nominations and winners'),
+  ('submissions:create', 'Submit films and follow their review'),
+  ('submissions:review', 'Review film submissions and write programmer notes'),
+  ('submissions:assign', 'Assign submission reviewers and follow review progress');
+
+INSERT INTO `role_permissions` (`role`, `permission`) VALUES
+  ('admin', 'movies:write'),
+  ('admin', 'stats:read'),
+  ('admin', 'users:read'),
+  ('admin', 'users:manage'),
+  ('admin', 'abuse:review'),
+  ('admin', 'festival:manage'),
+  ('admin', 'screenings:manage'),
+  ('admin', 'tickets:checkin'),
+  ('admin', 'jury:manage'),
+  ('admin', 'awards:manage'),
+  ('admin', 'submissions:review'),
+  ('admin', 'submissions:assign'),
+  ('curator', 'movies:write'),
+  ('curator', 'festival:manage'),
+  ('curator', 'screenings:manage'),
+  ('curator', 'submissions:review'),
+  ('curator', 'submissions:assign'),
+  ('analyst', 'stats:read'),
+  ('moderator', 'users:read'),
+  ('moderator', 'abuse:review'),
+  ('volunteer', 'tickets:checkin'),
+  ('juror', 'jury:score'),
+  ('filmmaker', 'submissions:create'),
+  ('programmer', 'submissions:review');
@@ -0,0 +1,20 @@
+-- movies.screenings definition
+-- ends_at is starts_at plus the movie's running time when the screening was scheduled.
+-- Writes lock the venue row so overlapping screenings cannot be scheduled concurrently.
+
+CREATE TABLE `screenings` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `movie_id` int NOT NULL,
+  `venue_id` int NOT NULL,
+  `starts_at` datetime NOT NULL,
+  `ends_at` datetime NOT NULL,
+  `language` varchar(50) NOT NULL DEFAULT '',
+  `subtitles` varchar(50) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `venue_starts_at_idx` (`venue_id`,`starts_at`),
+  KEY `starts_at_idx` (`starts_at`),
+  KEY `movie_id` (`movie_id`),
+  CONSTRAINT `fk_screenings_movie` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`),
+  CONSTRAINT `fk_screenings_venue` FOREIGN KEY (`venue_id`) REFERENCES `venues` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,13 @@
+-- movies.submission_conflicts definition
+-- Conflicts of interest declared by reviewers. A reviewer is never assigned a submission they declared a conflict on.
+
+CREATE TABLE `submission_conflicts` (
+  `submission_id` int NOT NULL,
+  `reviewer_id` int NOT NULL,
+  `reason` varchar(1000) NOT NULL,
+  `declared_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`submission_id`,`reviewer_id`),
+  KEY `reviewer_id` (`reviewer_id`),
+  CONSTRAINT `fk_submission_conflicts_submission` FOREIGN KEY (`submission_id`) REFERENCES `film_submissions` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_submission_conflicts_reviewer` FOREIGN KEY (`reviewer_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,16 @@
+-- movies.submission_notes definition
+-- Programmer notes on a submission. Shared notes are visible to the filmmaker, the others only to programmers.
+
+CREATE TABLE `submission_notes` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `submission_id` int NOT NULL,
+  `author_id` int DEFAULT NULL,
+  `body` text NOT NULL,
+  `shared` tinyint(1) NOT NULL DEFAULT '0',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `submission_idx` (`submission_id`,`created_at`),
+  KEY `author_id` (`author_id`),
+  CONSTRAINT `fk_submission_notes_submission` FOREIGN KEY (`submission_id`) REFERENCES `film_submissions` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_submission_notes_author` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,18 @@
+-- movies.submission_reviews definition
+-- Reviewers assigned to a submission. score (1-10) and comment stay NULL until the reviewer has reviewed the film.
+
+CREATE TABLE `submission_reviews` (
+  `submission_id` int NOT NULL,
+  `reviewer_id` int NOT NULL,
+  `assigned_by` int DEFAULT NULL,
+  `assigned_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `score` tinyint DEFAULT NULL,
+  `comment` text,
+  `reviewed_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`submission_id`,`reviewer_id`),
+  KEY `reviewer_idx` (`reviewer_id`,`assigned_at`),
+  KEY `assigned_by` (`assigned_by`),
+  CONSTRAINT `fk_submission_reviews_submission` FOREIGN KEY (`submission_id`) REFERENCES `film_submissions` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_submission_reviews_reviewer` FOREIGN KEY (`reviewer_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_submission_reviews_assigned_by` FOREIGN KEY (`assigned_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,28 @@
+-- movies.tickets definition
+-- One row per reservation of one or more seats for a screening.
+-- Held tickets count against capacity until hold_expires_at; seats are counted while the screening row is locked.
+-- code is a random value embedded in the signed QR payload presented at the door.
+-- checked_in_at is set once, when the ticket is scanned at the door.
+
+CREATE TABLE `tickets` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `screening_id` int NOT NULL,
+  `user_id` int NOT NULL,
+  `seats` int NOT NULL,
+  `status` enum('held','confirmed','cancelled','expired') NOT NULL DEFAULT 'held',
+  `code` varchar(64) NOT NULL,
+  `hold_expires_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `confirmed_at` timestamp NULL DEFAULT NULL,
+  `cancelled_at` timestamp NULL DEFAULT NULL,
+  `checked_in_at` timestamp NULL DEFAULT NULL,
+  `checked_in_by` int DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `code` (`code`),
+  KEY `screening_status_idx` (`screening_id`,`status`),
+  KEY `user_idx` (`user_id`,`created_at`),
+  KEY `checked_in_by` (`checked_in_by`),
+  CONSTRAINT `fk_tickets_screening` FOREIGN KEY (`screening_id`) REFERENCES `screenings` (`id`),
+  CONSTRAINT `fk_tickets_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_tickets_checked_in_by` FOREIGN KEY (`checked_in_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
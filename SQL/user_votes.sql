@@ -7,9 +7,11 @@ CREATE TABLE `user_votes` (
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
   `is_like` int DEFAULT NULL,
   `is_unlike` int DEFAULT NULL,
+  `ip_address` varchar(45) DEFAULT NULL,
+  `flagged` tinyint(1) NOT NULL DEFAULT '0',
   PRIMARY KEY (`id`),
   UNIQUE KEY `user_id` (`user_id`,`movie_id`),
   KEY `movie_id` (`movie_id`),
-  CONSTRAINT `user_votes_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`),
+  CONSTRAINT `user_votes_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
   CONSTRAINT `user_votes_ibfk_2` FOREIGN KEY (`movie_id`) REFERENCES `movies` (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
\ No newline at end of file
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"movies/mailer"
+	"movies/repository"
+	"movies/usecase"
+)
+
+// runAdmin handles the "admin" subcommands.
+func runAdmin(db *sql.DB, args []string) error {
+	if len(args) == 0 || args[0] != "bootstrap" {
+		return fmt.Errorf("usage: movies admin bootstrap -email <email> -password <password> [-gender <gender>]")
+	}
+
+	flags := flag.NewFlagSet("admin bootstrap", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the first admin")
+	password := flags.String("password", "", "password of the first admin")
+	gender := flags.String("gender", "-", "gender of the first admin")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *email == "" || *password == "" {
+		return fmt.Errorf("both -email and -password are required")
+	}
+
+	abuseUseCase := usecase.NewAbuseUseCase(repository.NewAbuseRepo(db))
+	usersUseCase := usecase.NewUsersUseCase(repository.NewUsersRepo(db), repository.NewTokensRepo(db), repository.NewRolesRepo(db), repository.NewLoginThrottleRepo(db), abuseUseCase, mailer.NewFromEnv())
+
+	admin, err := usersUseCase.BootstrapAdmin(*email, *password, *gender)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Admin account created: %s (id %d)", admin.Email, admin.Id)
+	return nil
+}
@@ -0,0 +1,29 @@
+package command
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Run executes a command-line subcommand, e.g. "recommend train", "admin bootstrap" or "keys generate".
+// connect opens the database; it is only called by commands that use it, so key management works offline.
+func Run(args []string, connect func() (*sql.DB, error)) error {
+	switch args[0] {
+	case "admin":
+		db, err := connect()
+		if err != nil {
+			return fmt.Errorf("failed to connect to DB: %w", err)
+		}
+		return runAdmin(db, args[1:])
+	case "keys":
+		return runKeys(args[1:])
+	case "recommend":
+		db, err := connect()
+		if err != nil {
+			return fmt.Errorf("failed to connect to DB: %w", err)
+		}
+		return runRecommend(db, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
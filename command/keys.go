@@ -0,0 +1,136 @@
+package command
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"movies/utils"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runKeys handles the "keys" subcommands that manage JWT signing keys.
+func runKeys(args []string) error {
+	usage := fmt.Errorf("usage: movies keys generate [-alg RS256|EdDSA] [-dir keys] | movies keys retire -kid <kid> [-dir keys]")
+	if len(args) == 0 {
+		return usage
+	}
+
+	switch args[0] {
+	case "generate":
+		return generateKey(args[1:])
+	case "retire":
+		return retireKey(args[1:])
+	default:
+		return usage
+	}
+}
+
+// generateKey writes a new private key named "<date>-<random>.pem" to the keys directory.
+func generateKey(args []string) error {
+	flags := flag.NewFlagSet("keys generate", flag.ContinueOnError)
+	alg := flags.String("alg", envOrDefault("JWT_ALG", "RS256"), "signing algorithm: RS256 or EdDSA")
+	dir := flags.String("dir", envOrDefault("JWT_KEYS_DIR", "keys"), "directory holding the keys")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var private crypto.Signer
+	var err error
+	switch *alg {
+	case "RS256":
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "EdDSA":
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", *alg)
+	}
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return err
+	}
+
+	suffix, err := utils.GenerateToken(4)
+	if err != nil {
+		return err
+	}
+	kid := time.Now().UTC().Format("20060102") + "-" + suffix
+
+	if err := os.MkdirAll(*dir, 0700); err != nil {
+		return err
+	}
+	path := filepath.Join(*dir, kid+".pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		return err
+	}
+
+	log.Printf("Generated %s key %s in %s", *alg, kid, path)
+	return nil
+}
+
+// retireKey replaces a private key with its public half, so it still verifies old tokens but can no longer sign.
+func retireKey(args []string) error {
+	flags := flag.NewFlagSet("keys retire", flag.ContinueOnError)
+	kid := flags.String("kid", "", "ID of the key to retire")
+	dir := flags.String("dir", envOrDefault("JWT_KEYS_DIR", "keys"), "directory holding the keys")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *kid == "" {
+		return fmt.Errorf("-kid is required")
+	}
+
+	path := filepath.Join(*dir, *kid+".pem")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return fmt.Errorf("%s does not hold a private key", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("unsupported private key in %s", path)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return err
+	}
+
+	publicPath := filepath.Join(*dir, *kid+".pub.pem")
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+
+	log.Printf("Retired key %s; %s only verifies tokens now", *kid, publicPath)
+	return nil
+}
+
+// envOrDefault returns an environment variable, or fallback when it is unset.
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"movies/repository"
+	"movies/usecase"
+)
+
+// runRecommend handles the "recommend" subcommands.
+func runRecommend(db *sql.DB, args []string) error {
+	if len(args) == 0 || args[0] != "train" {
+		return fmt.Errorf("usage: movies recommend train")
+	}
+
+	recommendationsUseCase := usecase.NewRecommendationsUseCase(repository.NewRecommendationsRepo(db), repository.NewStatsRepo(db), nil) // Training never filters by availability
+
+	count, err := recommendationsUseCase.Train()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Recommendation model trained: %d movie similarities stored", count)
+	return nil
+}
@@ -0,0 +1,127 @@
+package geo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ipRange is a block of addresses located in one country.
+type ipRange struct {
+	first   netip.Addr
+	last    netip.Addr
+	country string
+}
+
+// LocalDB resolves countries from a database file loaded in memory.
+type LocalDB struct {
+	ranges []ipRange // Sorted by first address, not overlapping
+}
+
+// LoadLocalDB reads a CSV database with one block per line, either "network,country" with the network
+// in CIDR notation ("203.0.113.0/24,ID") or "first,last,country" with an address range, as in the
+// free DB-IP and IP2Location country files. Lines starting with # are comments.
+func LoadLocalDB(path string) (*LocalDB, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+
+	db := &LocalDB{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GeoIP database: %w", err)
+		}
+
+		block, err := parseBlock(record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GeoIP database line %d: %w", line, err)
+		}
+		db.ranges = append(db.ranges, block)
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool { return db.ranges[i].first.Less(db.ranges[j].first) })
+	for i := 1; i < len(db.ranges); i++ {
+		if !db.ranges[i-1].last.Less(db.ranges[i].first) {
+			return nil, fmt.Errorf("invalid GeoIP database: %s overlaps %s", db.ranges[i].first, db.ranges[i-1].first)
+		}
+	}
+
+	return db, nil
+}
+
+// parseBlock parses a CIDR or address range record.
+func parseBlock(record []string) (ipRange, error) {
+	var block ipRange
+	switch len(record) {
+	case 2:
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(record[0]))
+		if err != nil {
+			return block, err
+		}
+		prefix = prefix.Masked()
+		block.first = prefix.Addr()
+		block.last = lastAddr(prefix)
+	case 3:
+		var err error
+		if block.first, err = netip.ParseAddr(strings.TrimSpace(record[0])); err != nil {
+			return block, err
+		}
+		if block.last, err = netip.ParseAddr(strings.TrimSpace(record[1])); err != nil {
+			return block, err
+		}
+		if block.first.Is4() != block.last.Is4() || block.last.Less(block.first) {
+			return block, fmt.Errorf("invalid range %s-%s", block.first, block.last)
+		}
+	default:
+		return block, fmt.Errorf("expected 2 or 3 fields, got %d", len(record))
+	}
+
+	block.country = strings.ToUpper(strings.TrimSpace(record[len(record)-1]))
+	if len(block.country) != 2 {
+		return block, fmt.Errorf("invalid country code %q", block.country)
+	}
+
+	return block, nil
+}
+
+// lastAddr returns the last address of a masked prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// Country looks the address up in the loaded blocks. IPv4 addresses mapped into IPv6 match IPv4 blocks.
+func (db *LocalDB) Country(ip string) (string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+	addr = addr.Unmap()
+
+	// Find the last block starting at or before the address
+	i := sort.Search(len(db.ranges), func(i int) bool { return addr.Less(db.ranges[i].first) }) - 1
+	if i < 0 || db.ranges[i].last.Less(addr) || db.ranges[i].first.Is4() != addr.Is4() {
+		return "", nil
+	}
+
+	return db.ranges[i].country, nil
+}
@@ -0,0 +1,30 @@
+package geo
+
+import (
+	"os"
+)
+
+// CountryResolver finds the country of an IP address.
+type CountryResolver interface {
+	// Country returns the ISO 3166-1 alpha-2 code of the address, or "" when it is unknown.
+	Country(ip string) (string, error)
+}
+
+// NewFromEnv builds the resolver selected by GEOIP_DB: a path loads the local database in that file,
+// an empty value resolves every address to an unknown country.
+func NewFromEnv() (CountryResolver, error) {
+	path := os.Getenv("GEOIP_DB")
+	if path == "" {
+		return UnknownResolver{}, nil
+	}
+
+	return LoadLocalDB(path)
+}
+
+// UnknownResolver resolves every address to an unknown country. It is used when no database is configured.
+type UnknownResolver struct{}
+
+// Country always reports an unknown country.
+func (UnknownResolver) Country(ip string) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AbuseHandler struct {
+	AbuseUseCase *usecase.AbuseUseCase
+}
+
+func NewAbuseHandler(AbuseUseCase *usecase.AbuseUseCase) *AbuseHandler {
+	return &AbuseHandler{AbuseUseCase: AbuseUseCase}
+}
+
+// ListFlags handles the admin request to list abuse flags, filtered by the optional status query.
+func (h *AbuseHandler) ListFlags(c *gin.Context) {
+	flags, err := h.AbuseUseCase.ListFlags(c.DefaultQuery("status", "open"))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid status") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be 'open', 'confirmed' or 'dismissed'"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch abuse flags"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flags": flags})
+}
+
+// ReviewFlag handles the admin decision to confirm or dismiss an abuse flag.
+func (h *AbuseHandler) ReviewFlag(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	flagID, err := strconv.Atoi(c.Param("flag_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid flag ID"})
+		return
+	}
+
+	var request model.RequestReviewFlag
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	err = h.AbuseUseCase.ReviewFlag(flagID, userClaims.UserID, request.Status)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid status") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be 'confirmed' or 'dismissed'"})
+		} else if strings.Contains(err.Error(), "flag not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Flag not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to review flag"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Flag reviewed successfully"})
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AvailabilityHandler struct {
+	AvailabilityUseCase *usecase.AvailabilityUseCase
+}
+
+func NewAvailabilityHandler(AvailabilityUseCase *usecase.AvailabilityUseCase) *AvailabilityHandler {
+	return &AvailabilityHandler{AvailabilityUseCase: AvailabilityUseCase}
+}
+
+// GetAvailability handles the request for the availability rules of a movie.
+func (h *AvailabilityHandler) GetAvailability(c *gin.Context) {
+	movieID, ok := parseIDParam(c, "movie_id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	availability, err := h.AvailabilityUseCase.GetAvailability(movieID)
+	if err != nil {
+		respondAvailabilityRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// SaveAvailability handles the request to set the availability rules of a movie.
+func (h *AvailabilityHandler) SaveAvailability(c *gin.Context) {
+	movieID, ok := parseIDParam(c, "movie_id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestMovieAvailability
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	availability, err := h.AvailabilityUseCase.SaveAvailability(movieID, &request)
+	if err != nil {
+		respondAvailabilityRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Availability saved", "availability": availability})
+}
+
+// DeleteAvailability handles the request to remove the availability rules of a movie.
+func (h *AvailabilityHandler) DeleteAvailability(c *gin.Context) {
+	movieID, ok := parseIDParam(c, "movie_id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	if err := h.AvailabilityUseCase.DeleteAvailability(movieID); err != nil {
+		respondAvailabilityRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Availability removed"})
+}
+
+// respondAvailabilityRuleError maps availability rule errors to HTTP responses, falling back to 500.
+func respondAvailabilityRuleError(c *gin.Context, err error) {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "movie not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case strings.Contains(msg, "availability not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie has no availability rules"})
+	case strings.Contains(msg, "invalid availability window"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "available_until must be after available_from"})
+	case strings.Contains(msg, "invalid max viewers"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_viewers must be at least 1"})
+	case strings.Contains(msg, "invalid country"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Countries must be at most 300 ISO 3166-1 alpha-2 codes"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process availability request"})
+	}
+}
+
+// respondPlaybackError writes the response for a movie that cannot be watched now and reports whether it did.
+func respondPlaybackError(c *gin.Context, err error) bool {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "movie outside availability window"):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Movie is not available online at this time"})
+	case strings.Contains(msg, "movie not available in country"):
+		c.JSON(http.StatusUnavailableForLegalReasons, gin.H{"error": "Movie is not available in your country"})
+	case strings.Contains(msg, "movie at capacity"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Maximum number of concurrent viewers reached"})
+	default:
+		return false
+	}
+	return true
+}
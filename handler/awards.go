@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AwardsHandler struct {
+	AwardsUseCase *usecase.AwardsUseCase
+}
+
+func NewAwardsHandler(AwardsUseCase *usecase.AwardsUseCase) *AwardsHandler {
+	return &AwardsHandler{AwardsUseCase: AwardsUseCase}
+}
+
+// ListAwards handles the public request for the awards of an edition. Winners of unpublished awards are hidden.
+func (h *AwardsHandler) ListAwards(c *gin.Context) {
+	// The optional edition query selects an edition; it defaults to the current one and "all" covers every edition
+	editionID, categories, err := h.AwardsUseCase.ListPublishedAwards(c.Query("edition"))
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"edition_id": editionID, "awards": categories})
+}
+
+// ListCategories handles the request to list awards with unpublished winners.
+func (h *AwardsHandler) ListCategories(c *gin.Context) {
+	editionID, categories, err := h.AwardsUseCase.ListCategories(c.Query("edition"))
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"edition_id": editionID, "awards": categories})
+}
+
+// GetCategory handles the request to fetch an award with unpublished winners.
+func (h *AwardsHandler) GetCategory(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	category, err := h.AwardsUseCase.GetCategory(categoryID)
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// CreateCategory handles the request to create an award.
+func (h *AwardsHandler) CreateCategory(c *gin.Context) {
+	var request model.RequestAwardCategory
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	category, err := h.AwardsUseCase.CreateCategory(&request)
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Award created successfully", "award": category})
+}
+
+// UpdateCategory handles the request to update an award.
+func (h *AwardsHandler) UpdateCategory(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestAwardCategory
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	category, err := h.AwardsUseCase.UpdateCategory(categoryID, &request)
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Award updated successfully", "award": category})
+}
+
+// DeleteCategory handles the request to delete an unpublished award.
+func (h *AwardsHandler) DeleteCategory(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	if err := h.AwardsUseCase.DeleteCategory(categoryID); err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Award deleted successfully"})
+}
+
+// SaveNomination handles the request to nominate a movie for an award.
+func (h *AwardsHandler) SaveNomination(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestNomination
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	category, err := h.AwardsUseCase.SaveNomination(categoryID, &request)
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Nomination saved successfully", "award": category})
+}
+
+// DeleteNomination handles the request to withdraw a nomination.
+func (h *AwardsHandler) DeleteNomination(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	movieID, ok := parseIDParam(c, "movie_id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	if err := h.AwardsUseCase.DeleteNomination(categoryID, movieID); err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Nomination withdrawn successfully"})
+}
+
+// SetWinners handles the request to choose the winners of an award.
+func (h *AwardsHandler) SetWinners(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestAwardWinners
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	category, err := h.AwardsUseCase.SetWinners(categoryID, &request)
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Winners saved successfully", "award": category})
+}
+
+// ComputeAudienceAward handles the request to award an audience award from the votes.
+func (h *AwardsHandler) ComputeAudienceAward(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	category, err := h.AwardsUseCase.ComputeAudienceAward(categoryID)
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Audience award computed successfully", "award": category})
+}
+
+// PublishCategory handles the request to announce the winners of an award.
+func (h *AwardsHandler) PublishCategory(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category_id", "Invalid award ID")
+	if !ok {
+		return
+	}
+
+	// The body is optional; without published_at the winners are announced immediately
+	var request model.RequestPublishAward
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+			return
+		}
+	}
+
+	category, err := h.AwardsUseCase.PublishCategory(categoryID, &request)
+	if err != nil {
+		respondAwardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Award publication scheduled", "award": category})
+}
+
+// respondAwardError maps award use case errors to HTTP responses, falling back to 500.
+func respondAwardError(c *gin.Context, err error) {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "award not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Award not found"})
+	case strings.Contains(msg, "edition not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Edition not found"})
+	case strings.Contains(msg, "movie not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case strings.Contains(msg, "nomination not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Nomination not found"})
+	case strings.Contains(msg, "invalid edition"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Edition must be an edition ID or \"all\""})
+	case strings.Contains(msg, "invalid award name"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Award name must be 1 to 100 characters"})
+	case strings.Contains(msg, "invalid award kind"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Award kind must be jury or audience"})
+	case strings.Contains(msg, "citation too long"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Citation must be at most 255 characters"})
+	case strings.Contains(msg, "movie not in edition"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Movie is not part of the award's edition"})
+	case strings.Contains(msg, "movie not nominated"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Winners must be nominated first"})
+	case strings.Contains(msg, "audience award is computed"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Audience award winners are computed from votes"})
+	case strings.Contains(msg, "not an audience award"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Only audience awards are computed from votes"})
+	case strings.Contains(msg, "no eligible movies"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "No movie has enough viewers and votes for the audience award"})
+	case strings.Contains(msg, "award name already exists"):
+		c.JSON(http.StatusConflict, gin.H{"error": "An award with this name already exists in the edition"})
+	case strings.Contains(msg, "award published"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Award winners are already published"})
+	case strings.Contains(msg, "award has no winner"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Award has no winner to publish"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process award request"})
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BallotsHandler struct {
+	BallotsUseCase *usecase.BallotsUseCase
+}
+
+func NewBallotsHandler(BallotsUseCase *usecase.BallotsUseCase) *BallotsHandler {
+	return &BallotsHandler{BallotsUseCase: BallotsUseCase}
+}
+
+// CastBallot handles an attendee's audience-award ballot for a movie.
+func (h *BallotsHandler) CastBallot(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	movieID, ok := parseIDParam(c, "movie_id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestBallot
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	ballot, err := h.BallotsUseCase.CastBallot(userClaims.UserID, movieID, &request)
+	if err != nil {
+		respondBallotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Ballot cast successfully", "ballot": ballot})
+}
+
+// ListMyBallots handles the request of a user for the ballots they cast.
+func (h *BallotsHandler) ListMyBallots(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	ballots, err := h.BallotsUseCase.ListUserBallots(userClaims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch ballots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ballots": ballots})
+}
+
+// GetTally handles the request for the audience-award tally of an edition.
+func (h *BallotsHandler) GetTally(c *gin.Context) {
+	editionID, err := strconv.Atoi(c.DefaultQuery("edition_id", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid edition ID"})
+		return
+	}
+
+	tally, err := h.BallotsUseCase.GetTally(editionID)
+	if err != nil {
+		respondBallotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tally)
+}
+
+// respondBallotError maps ballot use case errors to HTTP responses, falling back to 500.
+func respondBallotError(c *gin.Context, err error) {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "movie not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case strings.Contains(msg, "edition not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Edition not found"})
+	case strings.Contains(msg, "invalid score"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Score must be between 1 and 5"})
+	case strings.Contains(msg, "not an attendee"):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only people who attended a screening or watched the whole movie can vote"})
+	case strings.Contains(msg, "ballot already cast"):
+		c.JSON(http.StatusConflict, gin.H{"error": "You already voted for this movie"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process ballot request"})
+	}
+}
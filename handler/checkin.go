@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"movies/model"
+	"movies/usecase"
+	"movies/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CheckIn handles a ticket scan at the venue door.
+func (h *TicketsHandler) CheckIn(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestCheckIn
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	checkIn, err := h.TicketsUseCase.CheckIn(userClaims.UserID, &request)
+	if err != nil {
+		var duplicate *usecase.AlreadyCheckedInError
+		if errors.As(err, &duplicate) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Ticket already checked in", "checked_in_at": duplicate.CheckedInAt})
+			return
+		}
+		respondTicketError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ticket checked in", "check_in": checkIn})
+}
+
+// GetAttendance handles the request for the current attendance of a screening.
+func (h *TicketsHandler) GetAttendance(c *gin.Context) {
+	screeningID, ok := parseIDParam(c, "screening_id", "Invalid screening ID")
+	if !ok {
+		return
+	}
+
+	attendance, err := h.TicketsUseCase.GetAttendance(screeningID)
+	if err != nil {
+		respondTicketError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// StreamAttendance streams the attendance of a screening as server-sent "attendance" events. An event
+// is sent on connect, after every check-in on this server, and every ATTENDANCE_REFRESH_SECONDS (default 15).
+func (h *TicketsHandler) StreamAttendance(c *gin.Context) {
+	screeningID, ok := parseIDParam(c, "screening_id", "Invalid screening ID")
+	if !ok {
+		return
+	}
+
+	// Fail with a normal response before the stream starts when the screening is missing
+	attendance, err := h.TicketsUseCase.GetAttendance(screeningID)
+	if err != nil {
+		respondTicketError(c, err)
+		return
+	}
+
+	updates, unsubscribe := h.TicketsUseCase.AttendanceHub.Subscribe(screeningID)
+	defer unsubscribe()
+
+	refresh := time.NewTicker(time.Duration(utils.GetEnvInt("ATTENDANCE_REFRESH_SECONDS", 15)) * time.Second)
+	defer refresh.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // Keep reverse proxies from buffering the stream
+	c.SSEvent("attendance", attendance)
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-updates:
+		case <-refresh.C:
+		}
+
+		attendance, err := h.TicketsUseCase.GetAttendance(screeningID)
+		if err != nil {
+			log.Println("ERR stream attendance: ", err)
+			return false
+		}
+
+		c.SSEvent("attendance", attendance)
+		return true
+	})
+}
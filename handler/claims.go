@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"movies/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getUserClaims extracts the JWT claims stored by AuthMiddleware.
+// It writes an unauthorized response and returns false when the claims are missing or malformed.
+func getUserClaims(c *gin.Context) (*middleware.Claims, bool) {
+	claims, exists := c.Get("claims")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return nil, false
+	}
+
+	// Assert claims to the expected type
+	userClaims, ok := claims.(*middleware.Claims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+		return nil, false
+	}
+
+	return userClaims, true
+}
+
+// getViewer identifies the caller of a route guarded by OptionalAuthMiddleware and VisitorMiddleware.
+// Registered users are returned by user ID, anonymous visitors by their visitor ID.
+func getViewer(c *gin.Context) (int, string, bool) {
+	if _, exists := c.Get("claims"); exists {
+		userClaims, ok := getUserClaims(c)
+		if !ok {
+			return 0, "", false
+		}
+		return userClaims.UserID, "", true
+	}
+
+	visitorID := c.GetString("visitor_id")
+	if visitorID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Visitor not identified"})
+		return 0, "", false
+	}
+
+	return 0, visitorID, true
+}
+
+// getOptionalRole returns the role of the caller of a route guarded by OptionalAuthMiddleware, or ""
+// for anonymous callers.
+func getOptionalRole(c *gin.Context) string {
+	claims, exists := c.Get("claims")
+	if !exists {
+		return ""
+	}
+
+	userClaims, ok := claims.(*middleware.Claims)
+	if !ok {
+		return ""
+	}
+
+	return userClaims.Role
+}
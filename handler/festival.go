@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FestivalHandler struct {
+	FestivalUseCase *usecase.FestivalUseCase
+}
+
+func NewFestivalHandler(FestivalUseCase *usecase.FestivalUseCase) *FestivalHandler {
+	return &FestivalHandler{FestivalUseCase: FestivalUseCase}
+}
+
+// ListEditions handles the public request to list festival editions.
+func (h *FestivalHandler) ListEditions(c *gin.Context) {
+	editions, err := h.FestivalUseCase.ListEditions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch editions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"editions": editions})
+}
+
+// GetEdition handles the public request to fetch an edition with its programme sections.
+func (h *FestivalHandler) GetEdition(c *gin.Context) {
+	editionID, ok := parseIDParam(c, "edition_id", "Invalid edition ID")
+	if !ok {
+		return
+	}
+
+	edition, err := h.FestivalUseCase.GetEdition(editionID)
+	if err != nil {
+		respondFestivalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, edition)
+}
+
+// CreateEdition handles the request to create a festival edition.
+func (h *FestivalHandler) CreateEdition(c *gin.Context) {
+	var request model.RequestEdition
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	edition, err := h.FestivalUseCase.CreateEdition(&request)
+	if err != nil {
+		respondFestivalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Edition created successfully", "edition": edition})
+}
+
+// UpdateEdition handles the request to update a festival edition.
+func (h *FestivalHandler) UpdateEdition(c *gin.Context) {
+	editionID, ok := parseIDParam(c, "edition_id", "Invalid edition ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestEdition
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	edition, err := h.FestivalUseCase.UpdateEdition(editionID, &request)
+	if err != nil {
+		respondFestivalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Edition updated successfully", "edition": edition})
+}
+
+// DeleteEdition handles the request to delete a festival edition without movies.
+func (h *FestivalHandler) DeleteEdition(c *gin.Context) {
+	editionID, ok := parseIDParam(c, "edition_id", "Invalid edition ID")
+	if !ok {
+		return
+	}
+
+	if err := h.FestivalUseCase.DeleteEdition(editionID); err != nil {
+		respondFestivalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Edition deleted successfully"})
+}
+
+// CreateSection handles the request to add a programme section to an edition.
+func (h *FestivalHandler) CreateSection(c *gin.Context) {
+	editionID, ok := parseIDParam(c, "edition_id", "Invalid edition ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestSection
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	section, err := h.FestivalUseCase.CreateSection(editionID, &request)
+	if err != nil {
+		respondFestivalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Section created successfully", "section": section})
+}
+
+// UpdateSection handles the request to update a programme section.
+func (h *FestivalHandler) UpdateSection(c *gin.Context) {
+	editionID, ok := parseIDParam(c, "edition_id", "Invalid edition ID")
+	if !ok {
+		return
+	}
+	sectionID, ok := parseIDParam(c, "section_id", "Invalid section ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestSection
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	section, err := h.FestivalUseCase.UpdateSection(editionID, sectionID, &request)
+	if err != nil {
+		respondFestivalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Section updated successfully", "section": section})
+}
+
+// DeleteSection handles the request to delete a programme section.
+func (h *FestivalHandler) DeleteSection(c *gin.Context) {
+	editionID, ok := parseIDParam(c, "edition_id", "Invalid edition ID")
+	if !ok {
+		return
+	}
+	sectionID, ok := parseIDParam(c, "section_id", "Invalid section ID")
+	if !ok {
+		return
+	}
+
+	if err := h.FestivalUseCase.DeleteSection(editionID, sectionID); err != nil {
+		respondFestivalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Section deleted successfully"})
+}
+
+// parseIDParam reads a numeric path parameter, responding with 400 when it is not a number.
+func parseIDParam(c *gin.Context, name, message string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// respondFestivalError maps festival use case errors to HTTP responses, falling back to 500.
+func respondFestivalError(c *gin.Context, err error) {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "edition not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Edition not found"})
+	case strings.Contains(msg, "section not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case strings.Contains(msg, "invalid year"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Year must be between 1900 and 9999"})
+	case strings.Contains(msg, "invalid dates"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dates must be YYYY-MM-DD and ends_on must not be before starts_on"})
+	case strings.Contains(msg, "theme too long"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Theme must be at most 255 characters"})
+	case strings.Contains(msg, "invalid section name"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Section name must be 1 to 100 characters"})
+	case strings.Contains(msg, "edition year already exists"):
+		c.JSON(http.StatusConflict, gin.H{"error": "An edition for this year already exists"})
+	case strings.Contains(msg, "section name already exists"):
+		c.JSON(http.StatusConflict, gin.H{"error": "A section with this name already exists in the edition"})
+	case strings.Contains(msg, "edition has movies"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Edition still has movies assigned"})
+	case strings.Contains(msg, "section has jury"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Section still has a jury panel"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process festival request"})
+	}
+}
@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"errors"
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JuryHandler struct {
+	JuryUseCase *usecase.JuryUseCase
+}
+
+func NewJuryHandler(JuryUseCase *usecase.JuryUseCase) *JuryHandler {
+	return &JuryHandler{JuryUseCase: JuryUseCase}
+}
+
+// ListPanels handles the request to list every jury panel.
+func (h *JuryHandler) ListPanels(c *gin.Context) {
+	panels, err := h.JuryUseCase.ListPanels()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jury panels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"panels": panels})
+}
+
+// ListMyPanels handles the request to list the panels the logged-in juror sits on.
+func (h *JuryHandler) ListMyPanels(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	panels, err := h.JuryUseCase.ListUserPanels(userClaims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jury panels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"panels": panels})
+}
+
+// CreatePanel handles the request to set up the jury of a programme section.
+func (h *JuryHandler) CreatePanel(c *gin.Context) {
+	var request model.RequestJuryPanel
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	panel, err := h.JuryUseCase.CreatePanel(&request)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Jury panel created successfully", "panel": panel})
+}
+
+// GetPanel handles the request to fetch a panel with its jurors, criteria and movies.
+func (h *JuryHandler) GetPanel(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	panel, err := h.JuryUseCase.GetPanel(userClaims.UserID, userClaims.Role, panelID)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, panel)
+}
+
+// DeletePanel handles the request to delete an open jury panel.
+func (h *JuryHandler) DeletePanel(c *gin.Context) {
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	if err := h.JuryUseCase.DeletePanel(panelID); err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Jury panel deleted successfully"})
+}
+
+// SaveMember handles the request to add a juror to a panel or change the president.
+func (h *JuryHandler) SaveMember(c *gin.Context) {
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := parseIDParam(c, "user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestJuryMember
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	member, err := h.JuryUseCase.SaveMember(panelID, userID, &request)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Juror saved successfully", "member": member})
+}
+
+// DeleteMember handles the request to remove a juror from a panel.
+func (h *JuryHandler) DeleteMember(c *gin.Context) {
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := parseIDParam(c, "user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	if err := h.JuryUseCase.DeleteMember(panelID, userID); err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Juror removed successfully"})
+}
+
+// CreateCriterion handles the request to add a scoring criterion to a panel.
+func (h *JuryHandler) CreateCriterion(c *gin.Context) {
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestJuryCriterion
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	criterion, err := h.JuryUseCase.CreateCriterion(panelID, &request)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Criterion created successfully", "criterion": criterion})
+}
+
+// DeleteCriterion handles the request to remove a scoring criterion from a panel.
+func (h *JuryHandler) DeleteCriterion(c *gin.Context) {
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	criterionID, ok := parseIDParam(c, "criterion_id", "Invalid criterion ID")
+	if !ok {
+		return
+	}
+
+	if err := h.JuryUseCase.DeleteCriterion(panelID, criterionID); err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Criterion deleted successfully"})
+}
+
+// ListMyScores handles the request of a juror for their own scores on a panel.
+func (h *JuryHandler) ListMyScores(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	scores, err := h.JuryUseCase.ListUserScores(userClaims.UserID, panelID)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"scores": scores})
+}
+
+// SaveScores handles the request of a juror to score a movie.
+func (h *JuryHandler) SaveScores(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	movieID, ok := parseIDParam(c, "movie_id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestJuryScores
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	scores, err := h.JuryUseCase.SaveScores(userClaims.UserID, panelID, movieID, &request)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Scores saved successfully", "scores": scores})
+}
+
+// GetRankings handles the request of the jury president or a jury manager for a panel's ranking.
+func (h *JuryHandler) GetRankings(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	rankings, err := h.JuryUseCase.GetRankings(userClaims.UserID, userClaims.Role, panelID)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rankings": rankings})
+}
+
+// LockPanel handles the request to finalize a panel's scores.
+func (h *JuryHandler) LockPanel(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	panelID, ok := parseIDParam(c, "panel_id", "Invalid panel ID")
+	if !ok {
+		return
+	}
+
+	panel, err := h.JuryUseCase.LockPanel(userClaims.UserID, userClaims.Role, panelID)
+	if err != nil {
+		respondJuryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Jury scores locked", "panel": panel})
+}
+
+// respondJuryError maps jury use case errors to HTTP responses, falling back to 500.
+func respondJuryError(c *gin.Context, err error) {
+	var incomplete *usecase.JuryIncompleteError
+	if errors.As(err, &incomplete) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Every juror must score every movie on every criterion before locking", "missing_scores": incomplete.Missing})
+		return
+	}
+
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "panel not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Jury panel not found"})
+	case strings.Contains(msg, "section not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case strings.Contains(msg, "user not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case strings.Contains(msg, "juror not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Juror not found"})
+	case strings.Contains(msg, "criterion not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Criterion not found"})
+	case strings.Contains(msg, "movie not in section"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie is not judged by this panel"})
+	case strings.Contains(msg, "not jury president"):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the jury president can do this"})
+	case strings.Contains(msg, "invalid panel name"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Panel name must be 1 to 100 characters"})
+	case strings.Contains(msg, "invalid criterion name"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Criterion name must be 1 to 100 characters"})
+	case strings.Contains(msg, "invalid weight"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Weight must be between 1 and 100"})
+	case strings.Contains(msg, "invalid score"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Scores must be 1 to 10, one per criterion"})
+	case strings.Contains(msg, "user is not a juror"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "User's role does not allow jury scoring"})
+	case strings.Contains(msg, "section already has a jury"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Section already has a jury panel"})
+	case strings.Contains(msg, "criterion name already exists"):
+		c.JSON(http.StatusConflict, gin.H{"error": "A criterion with this name already exists on the panel"})
+	case strings.Contains(msg, "panel locked"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Jury scores are locked"})
+	case strings.Contains(msg, "panel has no criteria"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Panel has no scoring criteria"})
+	case strings.Contains(msg, "panel has no jurors"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Panel has no jurors"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process jury request"})
+	}
+}
@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"movies/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJWKS publishes the public keys that verify access tokens so other services can check them.
+func GetJWKS(c *gin.Context) {
+	// Keys change only on rotation; let verifiers cache them briefly
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": middleware.PublicJWKS()})
+}
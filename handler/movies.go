@@ -1,16 +1,12 @@
 package handler
 
 import (
-	"fmt"
 	"log"
-	"movies/model"
 	"movies/usecase"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,48 +21,12 @@ func NewMoviesHandler(MoviesUseCase *usecase.MoviesUseCase) *MoviesHandler {
 
 // Create handles movie creation with form data and file upload.
 func (h *MoviesHandler) Create(c *gin.Context) {
-	// Parse form data from the request.
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	duration := c.PostForm("duration")
-	artist := c.PostForm("artist")
-	genreIDStr := c.PostForm("genre_id")
-
-	// Initialize a slice to track missing fields for validation.
-	var missingFields []string
-
-	// Check for missing fields and append their names to the slice.
-	if title == "" {
-		missingFields = append(missingFields, "title")
-	}
-	if description == "" {
-		missingFields = append(missingFields, "description")
-	}
-	if duration == "" {
-		missingFields = append(missingFields, "duration")
-	}
-	if artist == "" {
-		missingFields = append(missingFields, "artist")
-	}
-	if genreIDStr == "" {
-		missingFields = append(missingFields, "genre_id")
-	}
-
-	// Return an error response if there are missing fields.
-	if len(missingFields) > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "fields": missingFields})
+	// Parse and validate the movie metadata from the form data.
+	movie, ok := parseMovieForm(c)
+	if !ok {
 		return
 	}
 
-	// Convert the genre_id from a string to an integer.
-	var genreID *int
-	if genreIDStr != "" {
-		gid, err := strconv.Atoi(genreIDStr)
-		if err == nil {
-			genreID = &gid
-		}
-	}
-
 	// Retrieve the uploaded file from the form data.
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -74,80 +34,19 @@ func (h *MoviesHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Validate the file size, allowing a maximum of 50 MB.
-	const maxFileSize = 50 << 20 // 50 MB.
-	if file.Size > maxFileSize {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File size exceeds the 50 MB limit"})
-		return
-	}
-
-	// Define a list of allowed MIME types for video files.
-	allowedMimeTypes := []string{
-		"video/mp4",
-		"video/x-matroska", // Supports .mkv format.
-		"video/x-msvideo",  // Supports .avi format.
-		"video/webm",
-	}
-
-	// Open and read the file header to validate its MIME type.
-	fileHeader, _ := file.Open()
-	defer fileHeader.Close()
-
-	buffer := make([]byte, 512)
-	_, err = fileHeader.Read(buffer)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
-		return
-	}
-
-	// Detect the file type and ensure it matches allowed MIME types.
-	fileType := http.DetectContentType(buffer)
-	validFile := false
-	for _, mimeType := range allowedMimeTypes {
-		if fileType == mimeType {
-			validFile = true
-			break
-		}
-	}
-
-	// Return an error response if the file type is not valid.
-	if !validFile {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type. Only movie/video files are allowed"})
-		return
-	}
-
-	// Ensure the "uploads" directory exists, creating it if necessary.
-	uploadPath := "uploads"
-	if _, err := os.Stat(uploadPath); os.IsNotExist(err) {
-		os.Mkdir(uploadPath, os.ModePerm)
-	}
-
-	// Generate a unique filename for the uploaded file.
-	filename := fmt.Sprintf("%d_%s", time.Now().Unix(), filepath.Base(file.Filename))
-	filePath := filepath.Join(uploadPath, filename)
-
-	// Save the uploaded file to the designated directory.
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+	// Validate and store the video, keeping its path as the watch URL.
+	watchURL, ok := saveUploadedVideo(c, file)
+	if !ok {
 		return
 	}
-
-	// Create a movie object with the provided form data and generated file path.
-	movie := &model.Movies{
-		Title:       title,
-		Description: description,
-		Duration:    duration,
-		Artist:      artist,
-		GenreID:     genreID,
-		WatchURL:    fmt.Sprintf("/%s", filePath),
-	}
+	movie.WatchURL = watchURL
 
 	// Use the use case layer to save the movie to the database.
 	movieID, err := h.MoviesUsecase.Create(movie)
 	if err != nil {
 		if strings.Contains(err.Error(), "genre not found") {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Genre not found"})
-		} else {
+		} else if !respondAssignmentError(c, err) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to track viewership"})
 		}
 		return
@@ -197,66 +96,35 @@ func (h *MoviesHandler) UpdateMovie(c *gin.Context) {
 		}
 
 	}
-
-	log.Println("genre id : ", genreId)
-
-	// Handle file upload: validate size, type, and save the file
-	// Update the movie's watch_url with the saved file path
-	if file, err := c.FormFile("file"); err == nil {
-		// Validate file size (max 50 MB)
-		const maxFileSize = 50 << 20 // 50 MB
-		if file.Size > maxFileSize {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "File size exceeds the 50 MB limit"})
-			return
-		}
-
-		// Validate file type (only movie/video files allowed)
-		allowedMimeTypes := []string{
-			"video/mp4", "video/x-matroska", "video/x-msvideo", "video/webm",
-		}
-
-		fileHeader, _ := file.Open()
-		defer fileHeader.Close()
-
-		// Check the file type
-		buffer := make([]byte, 512)
-		_, err = fileHeader.Read(buffer)
+	if editionIDStr := c.PostForm("edition_id"); editionIDStr != "" {
+		editionID, err := strconv.Atoi(editionIDStr)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid edition ID"})
 			return
 		}
-
-		fileType := http.DetectContentType(buffer)
-		validFile := false
-		for _, mimeType := range allowedMimeTypes {
-			if fileType == mimeType {
-				validFile = true
-				break
-			}
-		}
-
-		if !validFile {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type. Only movie/video files are allowed"})
+		updates["edition_id"] = editionID
+	}
+	if sectionIDStr := c.PostForm("section_id"); sectionIDStr != "" {
+		sectionID, err := strconv.Atoi(sectionIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
 			return
 		}
+		updates["section_id"] = sectionID
+	}
 
-		// Ensure upload directory exists and save the file
-		uploadPath := "uploads"
-		if _, err := os.Stat(uploadPath); os.IsNotExist(err) {
-			os.Mkdir(uploadPath, os.ModePerm)
-		}
-
-		// Generate a unique filename and save the uploaded file
-		filename := fmt.Sprintf("%d_%s", time.Now().Unix(), filepath.Base(file.Filename))
-		filePath := filepath.Join(uploadPath, filename)
+	log.Println("genre id : ", genreId)
 
-		if err := c.SaveUploadedFile(file, filePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+	// Handle file upload: validate size, type, and save the file
+	// Update the movie's watch_url with the saved file path
+	if file, err := c.FormFile("file"); err == nil {
+		watchURL, ok := saveUploadedVideo(c, file)
+		if !ok {
 			return
 		}
 
 		// Add the watch_url to the update fields
-		updates["watch_url"] = fmt.Sprintf("/%s", filePath)
+		updates["watch_url"] = watchURL
 	}
 
 	// If no fields were provided for update, return an error
@@ -272,7 +140,7 @@ func (h *MoviesHandler) UpdateMovie(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Genre not found"})
 		} else if strings.Contains(err.Error(), "movie not found") {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
-		} else {
+		} else if !respondAssignmentError(c, err) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to track viewership"})
 		}
 		return
@@ -302,10 +170,23 @@ func (h *MoviesHandler) GetAllMoviesWithPagination(c *gin.Context) {
 		return
 	}
 
+	// The optional edition_id limits the list to one festival edition.
+	editionID, err := strconv.Atoi(c.DefaultQuery("edition_id", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid edition_id"})
+		return
+	}
+
 	// Call the MoviesUsecase to fetch the list of movies with pagination.
-	// The use case function `GetAllMoviesWithPagination` takes the page and limit as arguments.
-	movies, err := h.MoviesUsecase.GetAllMoviesWithPagination(page, limit)
+	// The use case function `GetAllMoviesWithPagination` takes the page, limit and edition as arguments,
+	// and the caller's role and IP to hide movies that are not available to them.
+	includeUnavailable := c.Query("include_unavailable") == "true"
+	movies, err := h.MoviesUsecase.GetAllMoviesWithPagination(page, limit, editionID, getOptionalRole(c), c.ClientIP(), includeUnavailable)
 	if err != nil {
+		if strings.Contains(err.Error(), "unavailable movies forbidden") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only curators can list unavailable movies"})
+			return
+		}
 		// If an error occurs while fetching the movies, return a 500 Internal Server Error response with the error message.
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -322,7 +203,7 @@ func (h *MoviesHandler) GetAllMoviesWithPagination(c *gin.Context) {
 
 // SearchMovies handles the request to search for movies based on query parameters
 func (h *MoviesHandler) SearchMovies(c *gin.Context) {
-	// Get query parameters artist and genre_id
+	// Get query parameters title, description, artist, genre_id and edition_id
 	title := c.Query("title")
 	description := c.Query("description")
 	artist := c.Query("artist")
@@ -331,10 +212,19 @@ func (h *MoviesHandler) SearchMovies(c *gin.Context) {
 	if err != nil {
 		genreID = 0
 	}
+	editionID, err := strconv.Atoi(c.DefaultQuery("edition_id", "0"))
+	if err != nil {
+		editionID = 0
+	}
 
-	// Call usecase to search for movies
-	movies, err := h.MoviesUsecase.SearchMovies(title, description, artist, genreID)
+	// Call usecase to search for movies, hiding the ones not available to the caller
+	includeUnavailable := c.Query("include_unavailable") == "true"
+	movies, err := h.MoviesUsecase.SearchMovies(title, description, artist, genreID, editionID, getOptionalRole(c), c.ClientIP(), includeUnavailable)
 	if err != nil {
+		if strings.Contains(err.Error(), "unavailable movies forbidden") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only curators can list unavailable movies"})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -346,3 +236,48 @@ func (h *MoviesHandler) SearchMovies(c *gin.Context) {
 		"movies":   movies,
 	})
 }
+
+// Watch streams a movie's uploaded file after checking its availability for the caller's IP.
+// Movies hosted elsewhere are redirected to their watch URL.
+func (h *MoviesHandler) Watch(c *gin.Context) {
+	movieID, ok := parseIDParam(c, "movie_id", "Invalid movie ID")
+	if !ok {
+		return
+	}
+
+	movie, err := h.MoviesUsecase.GetWatchableMovie(movieID, c.ClientIP())
+	if err != nil {
+		if respondPlaybackError(c, err) {
+			return
+		}
+		if strings.Contains(err.Error(), "movie not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load movie"})
+		return
+	}
+
+	// Uploaded files are served from the uploads directory, with range requests for seeking
+	if strings.HasPrefix(movie.WatchURL, "/uploads/") {
+		c.File(filepath.Join("uploads", filepath.Base(movie.WatchURL)))
+		return
+	}
+
+	c.Redirect(http.StatusFound, movie.WatchURL)
+}
+
+// respondAssignmentError writes the response for festival edition and section errors and reports whether it did.
+func respondAssignmentError(c *gin.Context, err error) bool {
+	switch {
+	case strings.Contains(err.Error(), "edition not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Edition not found"})
+	case strings.Contains(err.Error(), "section not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case strings.Contains(err.Error(), "section not in edition"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Section does not belong to the edition"})
+	default:
+		return false
+	}
+	return true
+}
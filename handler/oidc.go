@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"log"
+	"movies/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookie binds a pending OIDC login to the browser that started it.
+const oidcStateCookie = "oidc_state"
+
+type OIDCHandler struct {
+	OIDCUseCase  *usecase.OIDCUseCase
+	UsersUsecase *usecase.UsersUseCase
+}
+
+func NewOIDCHandler(OIDCUseCase *usecase.OIDCUseCase, UsersUseCase *usecase.UsersUseCase) *OIDCHandler {
+	return &OIDCHandler{OIDCUseCase: OIDCUseCase, UsersUsecase: UsersUseCase}
+}
+
+// Login starts an OIDC login by redirecting the browser to the identity provider.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	if !h.OIDCUseCase.Enabled() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "OIDC login is not configured"})
+		return
+	}
+
+	state, authURL, err := h.OIDCUseCase.StartLogin(c.Request.Context())
+	if err != nil {
+		log.Println("Error starting OIDC login: ", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to contact identity provider"})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, 600, "/", "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback completes an OIDC login and returns our usual access and refresh tokens.
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	if !h.OIDCUseCase.Enabled() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "OIDC login is not configured"})
+		return
+	}
+
+	// The provider reports a refused or failed login through the error parameter
+	if providerError := c.Query("error"); providerError != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Login cancelled at identity provider", "reason": providerError})
+		return
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing code or state"})
+		return
+	}
+
+	// The state must come back to the browser that started the login
+	cookieState, err := c.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Login state mismatch; start the login again"})
+		return
+	}
+	c.SetCookie(oidcStateCookie, "", -1, "/", "", c.Request.TLS != nil, true)
+
+	user, err := h.OIDCUseCase.FinishLogin(c.Request.Context(), state, code, c.ClientIP())
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "invalid state"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Login expired; start the login again"})
+		case strings.Contains(err.Error(), "oidc exchange failed"):
+			log.Println("Error completing OIDC login: ", err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Identity provider login could not be verified"})
+		case strings.Contains(err.Error(), "oidc email missing"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identity provider did not share an email address"})
+		case strings.Contains(err.Error(), "email already registered"):
+			c.JSON(http.StatusConflict, gin.H{"error": "Email already registered; log in with your password"})
+		case strings.Contains(err.Error(), "account disabled"):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Account disabled"})
+		default:
+			log.Println("Error completing OIDC login: ", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete login"})
+		}
+		return
+	}
+
+	// Accounts with two-factor authentication still need their second factor
+	completeLogin(c, h.UsersUsecase, user)
+}
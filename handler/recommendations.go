@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"movies/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationsHandler struct {
+	RecommendationsUseCase *usecase.RecommendationsUseCase
+}
+
+func NewRecommendationsHandler(RecommendationsUseCase *usecase.RecommendationsUseCase) *RecommendationsHandler {
+	return &RecommendationsHandler{RecommendationsUseCase: RecommendationsUseCase}
+}
+
+// GetSimilarMovies handles the request to list movies similar to the given movie.
+func (h *RecommendationsHandler) GetSimilarMovies(c *gin.Context) {
+	movieID, err := strconv.Atoi(c.Param("movie_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie ID"})
+		return
+	}
+
+	limit, ok := recommendationLimit(c)
+	if !ok {
+		return
+	}
+
+	includeUnavailable := c.Query("include_unavailable") == "true"
+	movies, err := h.RecommendationsUseCase.GetSimilarMovies(movieID, limit, getOptionalRole(c), c.ClientIP(), includeUnavailable)
+	if err != nil {
+		if strings.Contains(err.Error(), "movie not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+		} else if strings.Contains(err.Error(), "unavailable movies forbidden") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only curators can list unavailable movies"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get similar movies"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"movies": movies})
+}
+
+// GetUserRecommendations handles the request to list recommended movies for the authenticated user.
+func (h *RecommendationsHandler) GetUserRecommendations(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := recommendationLimit(c)
+	if !ok {
+		return
+	}
+
+	includeUnavailable := c.Query("include_unavailable") == "true"
+	movies, err := h.RecommendationsUseCase.GetUserRecommendations(userClaims.UserID, limit, userClaims.Role, c.ClientIP(), includeUnavailable)
+	if err != nil {
+		if strings.Contains(err.Error(), "unavailable movies forbidden") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only curators can list unavailable movies"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"movies": movies})
+}
+
+// recommendationLimit parses the optional limit query (default 10, max 50).
+func recommendationLimit(c *gin.Context) (int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 50"})
+		return 0, false
+	}
+	return limit, true
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScreeningsHandler struct {
+	ScreeningsUseCase *usecase.ScreeningsUseCase
+}
+
+func NewScreeningsHandler(ScreeningsUseCase *usecase.ScreeningsUseCase) *ScreeningsHandler {
+	return &ScreeningsHandler{ScreeningsUseCase: ScreeningsUseCase}
+}
+
+// GetSchedule handles the public request for the screenings of a day, optionally at one venue.
+func (h *ScreeningsHandler) GetSchedule(c *gin.Context) {
+	venueID, err := strconv.Atoi(c.DefaultQuery("venue", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid venue ID"})
+		return
+	}
+
+	screenings, err := h.ScreeningsUseCase.GetSchedule(c.Query("date"), venueID)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"screenings": screenings})
+}
+
+// ListVenues handles the public request to list venues.
+func (h *ScreeningsHandler) ListVenues(c *gin.Context) {
+	venues, err := h.ScreeningsUseCase.ListVenues()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch venues"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"venues": venues})
+}
+
+// CreateVenue handles the request to create a venue.
+func (h *ScreeningsHandler) CreateVenue(c *gin.Context) {
+	var request model.RequestVenue
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	venue, err := h.ScreeningsUseCase.CreateVenue(&request)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Venue created successfully", "venue": venue})
+}
+
+// UpdateVenue handles the request to update a venue.
+func (h *ScreeningsHandler) UpdateVenue(c *gin.Context) {
+	venueID, ok := parseIDParam(c, "venue_id", "Invalid venue ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestVenue
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	venue, err := h.ScreeningsUseCase.UpdateVenue(venueID, &request)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Venue updated successfully", "venue": venue})
+}
+
+// DeleteVenue handles the request to delete a venue without screenings.
+func (h *ScreeningsHandler) DeleteVenue(c *gin.Context) {
+	venueID, ok := parseIDParam(c, "venue_id", "Invalid venue ID")
+	if !ok {
+		return
+	}
+
+	if err := h.ScreeningsUseCase.DeleteVenue(venueID); err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Venue deleted successfully"})
+}
+
+// GetScreening handles the public request to fetch a screening.
+func (h *ScreeningsHandler) GetScreening(c *gin.Context) {
+	screeningID, ok := parseIDParam(c, "screening_id", "Invalid screening ID")
+	if !ok {
+		return
+	}
+
+	screening, err := h.ScreeningsUseCase.GetScreening(screeningID)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, screening)
+}
+
+// CreateScreening handles the request to schedule a screening.
+func (h *ScreeningsHandler) CreateScreening(c *gin.Context) {
+	var request model.RequestScreening
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	screening, err := h.ScreeningsUseCase.CreateScreening(&request)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Screening created successfully", "screening": screening})
+}
+
+// UpdateScreening handles the request to change a screening.
+func (h *ScreeningsHandler) UpdateScreening(c *gin.Context) {
+	screeningID, ok := parseIDParam(c, "screening_id", "Invalid screening ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestScreening
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	screening, err := h.ScreeningsUseCase.UpdateScreening(screeningID, &request)
+	if err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Screening updated successfully", "screening": screening})
+}
+
+// DeleteScreening handles the request to remove a screening.
+func (h *ScreeningsHandler) DeleteScreening(c *gin.Context) {
+	screeningID, ok := parseIDParam(c, "screening_id", "Invalid screening ID")
+	if !ok {
+		return
+	}
+
+	if err := h.ScreeningsUseCase.DeleteScreening(screeningID); err != nil {
+		respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Screening deleted successfully"})
+}
+
+// respondScreeningError maps venue and screening errors to HTTP responses, falling back to 500.
+func respondScreeningError(c *gin.Context, err error) {
+	var conflict *usecase.ScreeningConflictError
+	if errors.As(err, &conflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Screening overlaps another screening at the venue", "conflict": conflict.Conflict})
+		return
+	}
+
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "venue not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+	case strings.Contains(msg, "screening not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Screening not found"})
+	case strings.Contains(msg, "movie not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case strings.Contains(msg, "invalid date"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Date must be YYYY-MM-DD"})
+	case strings.Contains(msg, "invalid venue"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Venue name must be 1 to 100 characters and address at most 255"})
+	case strings.Contains(msg, "invalid capacity"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Capacity must be positive"})
+	case strings.Contains(msg, "invalid language"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Language and subtitles must be at most 50 characters"})
+	case strings.Contains(msg, "movie duration unknown"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Movie duration cannot be read; update it to a value such as \"2 jam\" or \"105\""})
+	case strings.Contains(msg, "venue name already exists"):
+		c.JSON(http.StatusConflict, gin.H{"error": "A venue with this name already exists"})
+	case strings.Contains(msg, "venue has screenings"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Venue still has screenings"})
+	case strings.Contains(msg, "screening has tickets"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Screening has tickets"})
+	case strings.Contains(msg, "capacity below seats sold"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Capacity is below the seats already sold for an upcoming screening"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process screening request"})
+	}
+}
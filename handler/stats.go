@@ -2,9 +2,9 @@ package handler
 
 import (
 	"log"
-	"movies/middleware"
 	"movies/model"
 	"movies/usecase"
+	"movies/utils"
 	"net/http"
 	"strconv"
 	"strings"
@@ -27,11 +27,12 @@ func NewStatsHandler(statsUseCase *usecase.StatsUseCase) *StatsHandler {
 // GetMostViewedStats handles the request to retrieve the most viewed statistics.
 func (h *StatsHandler) GetMostViewedStats(c *gin.Context) {
 	// Call the use case to get the most viewed statistics data
-	stats, err := h.StatsUseCase.GetMostViewedStats()
+	// The optional edition_id query selects an edition; it defaults to the current one and "all" covers every edition
+	stats, err := h.StatsUseCase.GetMostViewedStats(c.Query("edition_id"))
 
-	// If there is an error in fetching statistics, return an internal server error response with the error message
+	// If there is an error in fetching statistics, return an error response with the error message
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondEditionError(c, err)
 		return
 	}
 
@@ -42,11 +43,12 @@ func (h *StatsHandler) GetMostViewedStats(c *gin.Context) {
 // GetMostVotedStats handles the request to retrieve the most voted statistics.
 func (h *StatsHandler) GetMostVotedStats(c *gin.Context) {
 	// Call the use case to get the most voted statistics data
-	stats, err := h.StatsUseCase.GetMostVotedStats()
+	// The optional edition_id query selects an edition; it defaults to the current one and "all" covers every edition
+	stats, err := h.StatsUseCase.GetMostVotedStats(c.Query("edition_id"))
 
-	// If there is an error in fetching statistics, return an internal server error response with the error message
+	// If there is an error in fetching statistics, return an error response with the error message
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondEditionError(c, err)
 		return
 	}
 
@@ -57,20 +59,10 @@ func (h *StatsHandler) GetMostVotedStats(c *gin.Context) {
 // VoteMovie handles voting for a movie
 func (h *StatsHandler) VoteMovie(c *gin.Context) {
 	// Extract the userID from JWT claims
-	claims, exists := c.Get("claims")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
-
-	// Assert claims to the expected type
-	userClaims, ok := claims.(*middleware.Claims)
+	userClaims, ok := getUserClaims(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 		return
 	}
-
-	// Retrieve the userID from the claims
 	userID := userClaims.UserID
 
 	movieID, err := strconv.Atoi(c.Param("movie_id"))
@@ -79,7 +71,7 @@ func (h *StatsHandler) VoteMovie(c *gin.Context) {
 		return
 	}
 
-	response, err := h.StatsUseCase.VoteMovie(userID, movieID)
+	response, err := h.StatsUseCase.VoteMovie(userID, movieID, c.ClientIP())
 	if err != nil {
 		if strings.Contains(err.Error(), "movie not found") {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
@@ -97,20 +89,10 @@ func (h *StatsHandler) VoteMovie(c *gin.Context) {
 // UnvoteMovie handles unvoting for a movie
 func (h *StatsHandler) UnvoteMovie(c *gin.Context) {
 	// Extract the userID from JWT claims
-	claims, exists := c.Get("claims")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
-
-	// Assert claims to the expected type
-	userClaims, ok := claims.(*middleware.Claims)
+	userClaims, ok := getUserClaims(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 		return
 	}
-
-	// Retrieve the userID from the claims
 	userID := userClaims.UserID
 
 	movieID, err := strconv.Atoi(c.Param("movie_id"))
@@ -138,20 +120,10 @@ func (h *StatsHandler) UnvoteMovie(c *gin.Context) {
 // GetUserVotedMovies handles the request to retrieve a list of user's voted movies.
 func (h *StatsHandler) GetUserVotedMovies(c *gin.Context) {
 	// Extract the userID from JWT claims
-	claims, exists := c.Get("claims")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
-
-	// Assert claims to the expected type
-	userClaims, ok := claims.(*middleware.Claims)
+	userClaims, ok := getUserClaims(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 		return
 	}
-
-	// Retrieve the userID from the claims
 	userID := userClaims.UserID
 	log.Println(userID)
 
@@ -169,20 +141,10 @@ func (h *StatsHandler) GetUserVotedMovies(c *gin.Context) {
 // TraceViewership handles tracking of viewership by watching duration
 func (h *StatsHandler) TraceViewership(c *gin.Context) {
 	// Extract the userID from JWT claims
-	claims, exists := c.Get("claims")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
-
-	// Assert claims to the expected type
-	userClaims, ok := claims.(*middleware.Claims)
+	userClaims, ok := getUserClaims(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 		return
 	}
-
-	// Retrieve the userID from the claims
 	userID := userClaims.UserID
 
 	// Parse movie_id and duration from the request
@@ -198,8 +160,8 @@ func (h *StatsHandler) TraceViewership(c *gin.Context) {
 		return
 	}
 
-	if request.Duration == 0 {
-		c.JSON(400, gin.H{"error": "Bad request", "message": "field duration required"})
+	if request.Duration <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request", "message": "field duration must be positive"})
 		return
 	}
 
@@ -213,7 +175,7 @@ func (h *StatsHandler) TraceViewership(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Viewership duration tracked successfully"})
 }
 
-// TrackView tracks the view of a movie, with userID taken from the JWT token
+// TrackView starts a playback session of a movie for a registered user or an anonymous visitor
 func (h *StatsHandler) TrackView(c *gin.Context) {
 	// Extract the movie_id from the URL parameters
 	movieIDStr := c.Param("movie_id")
@@ -223,35 +185,29 @@ func (h *StatsHandler) TrackView(c *gin.Context) {
 		return
 	}
 
-	// Extract the userID from JWT claims
-	claims, exists := c.Get("claims")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
-
-	// Assert claims to the expected type
-	userClaims, ok := claims.(*middleware.Claims)
+	// Identify the viewer from the JWT claims or the visitor cookie
+	userID, visitorID, ok := getViewer(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 		return
 	}
 
-	// Retrieve the userID from the claims
-	userID := userClaims.UserID
-
 	// Create the MovieView record with the current timestamp
 	movieView := &model.MovieView{
-		MovieID:  movieID,
-		UserID:   userID,
-		ViewedAt: time.Now().Format(time.RFC3339),
+		MovieID:   movieID,
+		UserID:    userID,
+		VisitorID: visitorID,
+		ViewedAt:  time.Now().Format(time.RFC3339),
+		IPAddress: c.ClientIP(),
 	}
 
-	log.Println("Movie View: ", movieView)
+	log.Println("Movie View: ", utils.Redact(movieView))
 
-	// Call the usecase to check and save view data
-	hasViewed, err := h.StatsUseCase.TrackMovieView(movieView)
+	// Call the usecase to start a new playback session
+	session, created, err := h.StatsUseCase.TrackMovieView(movieView)
 	if err != nil {
+		if respondPlaybackError(c, err) {
+			return
+		}
 		if strings.Contains(err.Error(), "movie not found") {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
 		} else {
@@ -260,12 +216,92 @@ func (h *StatsHandler) TrackView(c *gin.Context) {
 		return
 	}
 
-	// Respond with a message if the user has already viewed the movie
-	if hasViewed {
-		c.JSON(http.StatusOK, gin.H{"message": "User has already viewed this movie"})
+	// Respond with the existing session if the anonymous view was deduplicated
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"message": "Visitor has already viewed this movie recently", "session_id": session.SessionID})
+		return
+	}
+
+	// Respond with the session so the client can send heartbeats
+	c.JSON(http.StatusOK, gin.H{"message": "Viewership tracked successfully", "session_id": session.SessionID})
+}
+
+// HeartbeatSession records the seconds watched since the previous heartbeat of a playback session
+func (h *StatsHandler) HeartbeatSession(c *gin.Context) {
+	userID, visitorID, ok := getViewer(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestDuration
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	if request.Duration <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request", "message": "field duration must be positive"})
+		return
+	}
+
+	err := h.StatsUseCase.HeartbeatSession(userID, visitorID, c.Param("session_id"), request.Duration)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Heartbeat recorded"})
+}
+
+// EndSession closes a playback session, optionally adding the final watched seconds
+func (h *StatsHandler) EndSession(c *gin.Context) {
+	userID, visitorID, ok := getViewer(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional; a missing duration ends the session without adding time
+	var request model.RequestDuration
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+			return
+		}
+	}
+
+	if request.Duration < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request", "message": "field duration must not be negative"})
+		return
+	}
+
+	err := h.StatsUseCase.EndSession(userID, visitorID, c.Param("session_id"), request.Duration)
+	if err != nil {
+		respondSessionError(c, err)
 		return
 	}
 
-	// Respond with a success message for new views
-	c.JSON(http.StatusOK, gin.H{"message": "Viewership tracked successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Session ended"})
+}
+
+// respondSessionError maps playback session errors to HTTP responses
+func respondSessionError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "session not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+	} else if strings.Contains(err.Error(), "session already ended") {
+		c.JSON(http.StatusConflict, gin.H{"error": "Session already ended"})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update session"})
+	}
+}
+
+// respondEditionError maps edition lookup errors to HTTP responses, falling back to 500.
+func respondEditionError(c *gin.Context, err error) {
+	switch {
+	case strings.Contains(err.Error(), "invalid edition"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid edition ID"})
+	case strings.Contains(err.Error(), "edition not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Edition not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubmissionsHandler struct {
+	SubmissionsUseCase *usecase.SubmissionsUseCase
+}
+
+func NewSubmissionsHandler(SubmissionsUseCase *usecase.SubmissionsUseCase) *SubmissionsHandler {
+	return &SubmissionsHandler{SubmissionsUseCase: SubmissionsUseCase}
+}
+
+// Submit handles a filmmaker's submission with form data and file upload, like movie creation.
+func (h *SubmissionsHandler) Submit(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	// Parse and validate the film metadata; submissions also name their edition.
+	movie, ok := parseMovieForm(c)
+	if !ok {
+		return
+	}
+	if movie.EditionID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "fields": []string{"edition_id"}})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+
+	watchURL, ok := saveUploadedVideo(c, file)
+	if !ok {
+		return
+	}
+
+	submission, err := h.SubmissionsUseCase.Submit(&model.Submission{
+		UserID:      userClaims.UserID,
+		EditionID:   *movie.EditionID,
+		SectionID:   movie.SectionID,
+		Title:       movie.Title,
+		Description: movie.Description,
+		Duration:    movie.Duration,
+		Artist:      movie.Artist,
+		GenreID:     movie.GenreID,
+		WatchURL:    watchURL,
+	})
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Film submitted successfully", "submission": submission})
+}
+
+// ListMySubmissions handles the request of a filmmaker for their submissions.
+func (h *SubmissionsHandler) ListMySubmissions(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissions, err := h.SubmissionsUseCase.ListUserSubmissions(userClaims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch submissions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"submissions": submissions})
+}
+
+// GetMySubmission handles the request of a filmmaker for one of their submissions with shared notes.
+func (h *SubmissionsHandler) GetMySubmission(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+
+	submission, err := h.SubmissionsUseCase.GetUserSubmission(userClaims.UserID, submissionID)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// ListSubmissions handles the request of a programmer to list submissions.
+func (h *SubmissionsHandler) ListSubmissions(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	editionID, err := strconv.Atoi(c.DefaultQuery("edition_id", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid edition ID"})
+		return
+	}
+
+	submissions, err := h.SubmissionsUseCase.ListSubmissions(userClaims.Role, editionID, c.Query("status"))
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"submissions": submissions})
+}
+
+// GetSubmission handles the request of a programmer for a submission with all notes.
+func (h *SubmissionsHandler) GetSubmission(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+
+	submission, err := h.SubmissionsUseCase.GetSubmission(userClaims.Role, submissionID)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// UpdateStatus handles the request to move a submission to another status.
+func (h *SubmissionsHandler) UpdateStatus(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestSubmissionStatus
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	submission, err := h.SubmissionsUseCase.UpdateStatus(userClaims.Role, submissionID, &request)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Submission status updated", "submission": submission})
+}
+
+// AddNote handles the request to add a programmer note to a submission.
+func (h *SubmissionsHandler) AddNote(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestSubmissionNote
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	submission, err := h.SubmissionsUseCase.AddNote(userClaims.Role, userClaims.UserID, submissionID, &request)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Note added successfully", "submission": submission})
+}
+
+// Convert handles the request to turn a selected submission into a catalogue movie.
+func (h *SubmissionsHandler) Convert(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+
+	submission, err := h.SubmissionsUseCase.Convert(userClaims.Role, submissionID)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Submission added to the catalogue", "movie_id": submission.MovieID, "submission": submission})
+}
+
+// AssignReviewer handles the request of a review lead to assign a reviewer to a submission.
+func (h *SubmissionsHandler) AssignReviewer(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+	reviewerID, ok := parseIDParam(c, "user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	reviews, err := h.SubmissionsUseCase.AssignReviewer(userClaims.UserID, submissionID, reviewerID)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reviewer assigned", "reviews": reviews})
+}
+
+// UnassignReviewer handles the request of a review lead to remove a reviewer from a submission.
+func (h *SubmissionsHandler) UnassignReviewer(c *gin.Context) {
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+	reviewerID, ok := parseIDParam(c, "user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	if err := h.SubmissionsUseCase.UnassignReviewer(submissionID, reviewerID); err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reviewer removed"})
+}
+
+// ListAssigned handles the request of a reviewer for the submissions assigned to them.
+func (h *SubmissionsHandler) ListAssigned(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissions, err := h.SubmissionsUseCase.ListAssignedSubmissions(userClaims.UserID, userClaims.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch submissions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"submissions": submissions})
+}
+
+// SaveReview handles a reviewer's score and comment on a submission assigned to them.
+func (h *SubmissionsHandler) SaveReview(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestSubmissionReview
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	review, err := h.SubmissionsUseCase.SaveReview(userClaims.UserID, submissionID, &request)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Review saved", "review": review})
+}
+
+// DeclareConflict handles a reviewer's conflict-of-interest declaration on a submission.
+func (h *SubmissionsHandler) DeclareConflict(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	submissionID, ok := parseIDParam(c, "submission_id", "Invalid submission ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestSubmissionConflict
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	if err := h.SubmissionsUseCase.DeclareConflict(userClaims.UserID, submissionID, &request); err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Conflict of interest declared"})
+}
+
+// GetDashboard handles the request of a review lead for the review progress of an edition.
+func (h *SubmissionsHandler) GetDashboard(c *gin.Context) {
+	editionID, err := strconv.Atoi(c.DefaultQuery("edition_id", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid edition ID"})
+		return
+	}
+
+	dashboard, err := h.SubmissionsUseCase.GetDashboard(editionID)
+	if err != nil {
+		respondSubmissionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// respondSubmissionError maps submission use case errors to HTTP responses, falling back to 500.
+func respondSubmissionError(c *gin.Context, err error) {
+	if respondAssignmentError(c, err) {
+		return
+	}
+
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "submission not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+	case strings.Contains(msg, "genre not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Genre not found"})
+	case strings.Contains(msg, "invalid submission"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Title must be at most 255 characters and duration at most 10"})
+	case strings.Contains(msg, "invalid status change"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Submission cannot move to this status"})
+	case strings.Contains(msg, "invalid status"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be received, in_review, selected or rejected"})
+	case strings.Contains(msg, "invalid note"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Note must be 1 to 5000 characters"})
+	case strings.Contains(msg, "submission already converted"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Submission is already in the catalogue"})
+	case strings.Contains(msg, "submission not selected"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Only selected submissions can be added to the catalogue"})
+	case strings.Contains(msg, "submission not in review"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Submission is not in review"})
+	case strings.Contains(msg, "submission not assigned"):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Submission is not assigned to you"})
+	case strings.Contains(msg, "user not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case strings.Contains(msg, "user is not a reviewer"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User's role does not grant submissions:review"})
+	case strings.Contains(msg, "reviewer has conflict"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Reviewer has a conflict of interest with this submission"})
+	case strings.Contains(msg, "reviewer not assigned"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reviewer is not assigned to this submission"})
+	case strings.Contains(msg, "invalid review"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Score must be 1 to 10 and comment at most 5000 characters"})
+	case strings.Contains(msg, "invalid conflict"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reason must be 1 to 1000 characters"})
+	case strings.Contains(msg, "submission changed"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Submission was changed by someone else; reload and retry"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process submission request"})
+	}
+}
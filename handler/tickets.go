@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"movies/model"
+	"movies/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketsHandler struct {
+	TicketsUseCase *usecase.TicketsUseCase
+}
+
+func NewTicketsHandler(TicketsUseCase *usecase.TicketsUseCase) *TicketsHandler {
+	return &TicketsHandler{TicketsUseCase: TicketsUseCase}
+}
+
+// ReserveSeats handles the request to hold seats for a screening.
+func (h *TicketsHandler) ReserveSeats(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	screeningID, ok := parseIDParam(c, "screening_id", "Invalid screening ID")
+	if !ok {
+		return
+	}
+
+	var request model.RequestReserveTickets
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	ticket, err := h.TicketsUseCase.ReserveSeats(userClaims.UserID, screeningID, request.Seats)
+	if err != nil {
+		respondTicketError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Seats held; confirm the ticket before the hold expires", "ticket": ticket})
+}
+
+// ListTickets handles the request to list the logged-in user's tickets.
+func (h *TicketsHandler) ListTickets(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	tickets, err := h.TicketsUseCase.ListTickets(userClaims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tickets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tickets": tickets})
+}
+
+// GetTicket handles the request to fetch one of the logged-in user's tickets.
+func (h *TicketsHandler) GetTicket(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseIDParam(c, "ticket_id", "Invalid ticket ID")
+	if !ok {
+		return
+	}
+
+	ticket, err := h.TicketsUseCase.GetTicket(userClaims.UserID, ticketID)
+	if err != nil {
+		respondTicketError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// ConfirmTicket handles the request to confirm a held ticket.
+func (h *TicketsHandler) ConfirmTicket(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseIDParam(c, "ticket_id", "Invalid ticket ID")
+	if !ok {
+		return
+	}
+
+	ticket, err := h.TicketsUseCase.ConfirmTicket(userClaims.UserID, ticketID)
+	if err != nil {
+		respondTicketError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ticket confirmed successfully", "ticket": ticket})
+}
+
+// CancelTicket handles the request to cancel a ticket.
+func (h *TicketsHandler) CancelTicket(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseIDParam(c, "ticket_id", "Invalid ticket ID")
+	if !ok {
+		return
+	}
+
+	if err := h.TicketsUseCase.CancelTicket(userClaims.UserID, ticketID); err != nil {
+		respondTicketError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ticket cancelled successfully"})
+}
+
+// respondTicketError maps ticketing errors to HTTP responses, falling back to 500.
+func respondTicketError(c *gin.Context, err error) {
+	var unavailable *usecase.TicketsUnavailableError
+	if errors.As(err, &unavailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Not enough seats available", "available": unavailable.Available})
+		return
+	}
+
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "screening not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Screening not found"})
+	case strings.Contains(msg, "ticket not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Ticket not found"})
+	case strings.Contains(msg, "invalid seats"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid number of seats"})
+	case strings.Contains(msg, "screening started"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Screening has already started"})
+	case strings.Contains(msg, "hold expired"):
+		c.JSON(http.StatusGone, gin.H{"error": "Hold expired; reserve the seats again"})
+	case strings.Contains(msg, "ticket already confirmed"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Ticket is already confirmed"})
+	case strings.Contains(msg, "ticket cancelled"), strings.Contains(msg, "ticket not active"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Ticket is no longer active"})
+	case strings.Contains(msg, "invalid ticket code"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket code"})
+	case strings.Contains(msg, "ticket not valid"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Ticket is not confirmed"})
+	case strings.Contains(msg, "wrong screening"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Ticket is for another screening"})
+	case strings.Contains(msg, "check-in closed"):
+		c.JSON(http.StatusConflict, gin.H{"error": "Check-in is not open for this screening"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process ticket request"})
+	}
+}
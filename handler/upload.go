@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"fmt"
+	"mime/multipart"
+	"movies/model"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxVideoSize is the largest video upload accepted, 50 MB.
+const maxVideoSize = 50 << 20
+
+// allowedVideoTypes lists the MIME types accepted for video uploads.
+var allowedVideoTypes = []string{
+	"video/mp4",
+	"video/x-matroska", // Supports .mkv format.
+	"video/x-msvideo",  // Supports .avi format.
+	"video/webm",
+}
+
+// saveUploadedVideo validates the size and content type of an uploaded video and stores it in the
+// uploads directory. It returns the watch URL of the stored file, or writes an error response and
+// returns false when the file is rejected.
+func saveUploadedVideo(c *gin.Context, file *multipart.FileHeader) (string, bool) {
+	if file.Size > maxVideoSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File size exceeds the 50 MB limit"})
+		return "", false
+	}
+
+	// Open and read the file header to validate its MIME type.
+	fileHeader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return "", false
+	}
+	defer fileHeader.Close()
+
+	buffer := make([]byte, 512)
+	if _, err := fileHeader.Read(buffer); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return "", false
+	}
+
+	// Detect the file type and ensure it matches allowed MIME types.
+	fileType := http.DetectContentType(buffer)
+	validFile := false
+	for _, mimeType := range allowedVideoTypes {
+		if fileType == mimeType {
+			validFile = true
+			break
+		}
+	}
+	if !validFile {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type. Only movie/video files are allowed"})
+		return "", false
+	}
+
+	// Ensure the "uploads" directory exists, creating it if necessary.
+	uploadPath := "uploads"
+	if _, err := os.Stat(uploadPath); os.IsNotExist(err) {
+		os.Mkdir(uploadPath, os.ModePerm)
+	}
+
+	// Generate a unique filename for the uploaded file.
+	filename := fmt.Sprintf("%d_%s", time.Now().Unix(), filepath.Base(file.Filename))
+	filePath := filepath.Join(uploadPath, filename)
+
+	if err := c.SaveUploadedFile(file, filePath); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		return "", false
+	}
+
+	return fmt.Sprintf("/%s", filePath), true
+}
+
+// parseMovieForm reads the metadata of a new movie from form data: title, description, duration,
+// artist and genre_id are required, edition_id and section_id optional. It writes an error response
+// and returns false when fields are missing or malformed.
+func parseMovieForm(c *gin.Context) (*model.Movies, bool) {
+	movie := &model.Movies{
+		Title:       c.PostForm("title"),
+		Description: c.PostForm("description"),
+		Duration:    c.PostForm("duration"),
+		Artist:      c.PostForm("artist"),
+	}
+	genreIDStr := c.PostForm("genre_id")
+
+	// Initialize a slice to track missing fields for validation.
+	var missingFields []string
+
+	// Check for missing fields and append their names to the slice.
+	if movie.Title == "" {
+		missingFields = append(missingFields, "title")
+	}
+	if movie.Description == "" {
+		missingFields = append(missingFields, "description")
+	}
+	if movie.Duration == "" {
+		missingFields = append(missingFields, "duration")
+	}
+	if movie.Artist == "" {
+		missingFields = append(missingFields, "artist")
+	}
+	if genreIDStr == "" {
+		missingFields = append(missingFields, "genre_id")
+	}
+
+	// Return an error response if there are missing fields.
+	if len(missingFields) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "fields": missingFields})
+		return nil, false
+	}
+
+	// Convert the genre_id from a string to an integer.
+	genreID, err := strconv.Atoi(genreIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid genre ID"})
+		return nil, false
+	}
+	movie.GenreID = &genreID
+
+	// Convert the optional edition_id and section_id to integers.
+	if editionIDStr := c.PostForm("edition_id"); editionIDStr != "" {
+		editionID, err := strconv.Atoi(editionIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid edition ID"})
+			return nil, false
+		}
+		movie.EditionID = &editionID
+	}
+	if sectionIDStr := c.PostForm("section_id"); sectionIDStr != "" {
+		sectionID, err := strconv.Atoi(sectionIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+			return nil, false
+		}
+		movie.SectionID = &sectionID
+	}
+
+	return movie, true
+}
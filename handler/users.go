@@ -1,16 +1,19 @@
 package handler
 
 import (
-	"database/sql"
+	"errors"
 	"log"
+	"movies/middleware"
 	"movies/model"
 	"movies/usecase"
-	"os"
+	"movies/utils"
+	"net/http"
+	"net/mail"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -23,11 +26,11 @@ func NewUsersHandler(UsersUseCase *usecase.UsersUseCase) *UsersHandler {
 }
 
 func (ph *UsersHandler) Register(c *gin.Context) {
-	var users model.Users
+	var request model.RequestRegister
 
-	// Bind request body to users struct
-	err := c.ShouldBind(&users)
-	log.Println("Users : ", users)
+	// Bind request body to the registration request
+	err := c.ShouldBind(&request)
+	log.Println("Register request: ", utils.Redact(request))
 
 	if err != nil {
 		log.Println("Error binding: ", err)
@@ -36,25 +39,33 @@ func (ph *UsersHandler) Register(c *gin.Context) {
 	}
 
 	// Validate that all required fields are provided
-	if users.Email == "" || users.Password == "" || users.Gender == "" || users.Role == "" {
-		c.JSON(400, gin.H{"error": "Bad request", "message": "All fields (email, password, gender, role) are required"})
+	if request.Email == "" || request.Password == "" || request.Gender == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "All fields (email, password, gender) are required"})
 		return
 	}
 
-	// Validate the Role field
-	if users.Role != "admin" && users.Role != "user" {
-		c.JSON(400, gin.H{"error": "Bad request", "message": "Role must be 'admin' or 'user'"})
+	// Verification emails need a deliverable address
+	if _, err := mail.ParseAddress(request.Email); err != nil {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "Email is invalid"})
 		return
 	}
 
 	// Hash the password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(users.Password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
 		log.Println("Error hashing password: ", err)
 		c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to hash password"})
 		return
 	}
-	users.Password = string(hashedPassword)
+
+	// Self-service registration always creates regular users; admins are promoted by other admins
+	users := model.Users{
+		Email:          request.Email,
+		Password:       string(hashedPassword),
+		Gender:         request.Gender,
+		Role:           "user",
+		RegistrationIP: c.ClientIP(),
+	}
 
 	// Call the usecase to create the user
 	UsersResult, err := ph.UsersUsecase.Create(&users)
@@ -63,69 +74,568 @@ func (ph *UsersHandler) Register(c *gin.Context) {
 			c.JSON(400, gin.H{"error": "Bad request", "message": "Email already exists"})
 		} else {
 			log.Println("Error creating user: ", err)
-			c.JSON(500, gin.H{"error": "Internal server error", "message": err.Error()})
+			c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to register user"})
 		}
 		return
 	}
 
 	// Respond with success
-	c.JSON(201, gin.H{"message": "User registered successfully", "user": UsersResult})
+	c.JSON(201, gin.H{"message": "User registered successfully", "user": UsersResult.Profile()})
 }
 
 func (ph *UsersHandler) Login(c *gin.Context) {
-	var user model.Users
-	var jwtSecret = []byte(os.Getenv("JWT_SECRET")) // Securely store this key
-
-	// JWT claims structure
-	type Claims struct {
-		UserID int    `json:"id"`   // User ID in the JWT claim
-		Role   string `json:"role"` // User role in the JWT claim
-		jwt.RegisteredClaims
-	}
+	var request model.RequestLogin
 
-	// Bind JSON request to user struct
-	if err := c.ShouldBind(&user); err != nil {
+	// Bind JSON request to the login request
+	if err := c.ShouldBind(&request); err != nil {
 		log.Println("ERR: ", err)
 		c.JSON(400, gin.H{"error": "Bad request", "message": err.Error()})
 		return
 	}
 
-	log.Println("User from handler: ", user)
+	log.Println("Login request: ", utils.Redact(request))
+
+	// Check the credentials; unknown emails and wrong passwords get the same answer
+	result, err := ph.UsersUsecase.Login(request.Email, request.Password, c.ClientIP())
+	if err != nil {
+		var locked *usecase.LoginLockedError
+		if errors.As(err, &locked) {
+			c.Header("Retry-After", strconv.Itoa(int(locked.RetryAfter.Seconds())))
+			c.JSON(429, gin.H{"error": "Too Many Requests", "message": "Too many failed login attempts; try again later"})
+		} else if errors.Is(err, usecase.ErrInvalidCredentials) {
+			c.JSON(401, gin.H{"error": "Unauthorized", "message": "Email or password is invalid"})
+		} else if strings.Contains(err.Error(), "account disabled") {
+			c.JSON(403, gin.H{"error": "Forbidden", "message": "Account disabled"})
+		} else {
+			log.Println("ERR: ", err)
+			c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to log in"})
+		}
+		return
+	}
+
+	completeLogin(c, ph.UsersUsecase, result)
+}
+
+// LoginTwoFactor completes the login of an account with two-factor authentication.
+func (ph *UsersHandler) LoginTwoFactor(c *gin.Context) {
+	var request model.RequestTwoFactorLogin
+	if err := c.ShouldBindJSON(&request); err != nil || request.MFAToken == "" || request.Code == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "fields mfa_token and code required"})
+		return
+	}
+
+	user, err := ph.UsersUsecase.CompleteTwoFactorLogin(request.MFAToken, request.Code, c.ClientIP())
+	if err != nil {
+		var locked *usecase.LoginLockedError
+		if errors.As(err, &locked) {
+			c.Header("Retry-After", strconv.Itoa(int(locked.RetryAfter.Seconds())))
+			c.JSON(429, gin.H{"error": "Too Many Requests", "message": "Too many failed login attempts; try again later"})
+		} else if strings.Contains(err.Error(), "invalid mfa token") {
+			c.JSON(401, gin.H{"error": "Unauthorized", "message": "Login expired; log in with your password again"})
+		} else if strings.Contains(err.Error(), "invalid code") {
+			c.JSON(401, gin.H{"error": "Unauthorized", "message": "Code is invalid; log in with your password again"})
+		} else if strings.Contains(err.Error(), "account disabled") {
+			c.JSON(403, gin.H{"error": "Forbidden", "message": "Account disabled"})
+		} else {
+			log.Println("Error completing two-factor login: ", err)
+			c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to log in"})
+		}
+		return
+	}
+
+	issueTokens(c, ph.UsersUsecase, user, true)
+}
+
+// Refresh exchanges a refresh token for a new access token and a rotated refresh token.
+func (ph *UsersHandler) Refresh(c *gin.Context) {
+	var request model.RequestRefreshToken
+	if err := c.ShouldBindJSON(&request); err != nil || request.RefreshToken == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "field refresh_token required"})
+		return
+	}
 
-	// Get user data from the database
-	result, err := ph.UsersUsecase.GetUser(&user)
+	user, refreshToken, twoFactor, err := ph.UsersUsecase.RotateRefreshToken(request.RefreshToken)
 	if err != nil {
-		// Check if the error is due to no rows found
-		if err == sql.ErrNoRows {
-			c.JSON(404, gin.H{"error": "Not Found", "message": "Email not registered. Please register first."})
+		if strings.Contains(err.Error(), "invalid refresh token") {
+			c.JSON(401, gin.H{"error": "Unauthorized", "message": "Refresh token is invalid or expired"})
+		} else if strings.Contains(err.Error(), "account disabled") {
+			c.JSON(403, gin.H{"error": "Forbidden", "message": "Account disabled"})
+		} else if strings.Contains(err.Error(), "refresh token reuse detected") {
+			c.JSON(401, gin.H{"error": "Unauthorized", "message": "Refresh token was already used; please log in again"})
+		} else {
+			log.Println("Error refreshing token: ", err)
+			c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to refresh token"})
+		}
+		return
+	}
+
+	respondWithTokens(c, "Token refreshed", user, refreshToken, twoFactor)
+}
+
+// Logout revokes the current access token and the refresh token sent in the body, if any.
+func (ph *UsersHandler) Logout(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	// The refresh token is optional; without it only the access token is revoked
+	var request model.RequestRefreshToken
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(400, gin.H{"error": "Bad request", "message": err.Error()})
 			return
 		}
+	}
 
-		// Handle other errors
-		log.Println("ERR: ", err)
-		c.JSON(500, gin.H{"error": "Internal server error", "message": err.Error()})
+	var expiresAt time.Time
+	if userClaims.ExpiresAt != nil {
+		expiresAt = userClaims.ExpiresAt.Time
+	}
+
+	err := ph.UsersUsecase.Logout(userClaims.UserID, userClaims.ID, expiresAt, request.RefreshToken)
+	if err != nil {
+		log.Println("Error logging out: ", err)
+		c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to log out"})
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Logout successful"})
+}
+
+// VerifyEmail confirms a user's email address with the token sent on registration.
+func (ph *UsersHandler) VerifyEmail(c *gin.Context) {
+	var request model.RequestVerifyEmail
+	if err := c.ShouldBindJSON(&request); err != nil || request.Token == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "field token required"})
+		return
+	}
+
+	if err := ph.UsersUsecase.VerifyEmail(request.Token); err != nil {
+		if strings.Contains(err.Error(), "invalid token") {
+			c.JSON(400, gin.H{"error": "Bad request", "message": "Token is invalid or expired"})
+		} else {
+			log.Println("Error verifying email: ", err)
+			c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to verify email"})
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Email verified successfully"})
+}
+
+// ForgotPassword emails a password reset token. It always answers the same way so it cannot be used to discover accounts.
+func (ph *UsersHandler) ForgotPassword(c *gin.Context) {
+	var request model.RequestForgotPassword
+	if err := c.ShouldBindJSON(&request); err != nil || request.Email == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "field email required"})
+		return
+	}
+
+	if err := ph.UsersUsecase.ForgotPassword(request.Email); err != nil {
+		log.Println("Error sending password reset: ", err)
+	}
+
+	c.JSON(200, gin.H{"message": "If the email is registered, a password reset link has been sent"})
+}
+
+// ResetPassword sets a new password using a password reset token.
+func (ph *UsersHandler) ResetPassword(c *gin.Context) {
+	var request model.RequestResetPassword
+	if err := c.ShouldBindJSON(&request); err != nil || request.Token == "" || request.Password == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "fields token and password required"})
+		return
+	}
+
+	if err := ph.UsersUsecase.ResetPassword(request.Token, request.Password); err != nil {
+		if strings.Contains(err.Error(), "invalid token") {
+			c.JSON(400, gin.H{"error": "Bad request", "message": "Token is invalid or expired"})
+		} else {
+			log.Println("Error resetting password: ", err)
+			c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to reset password"})
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Password reset successfully; please log in again"})
+}
+
+// GetMe returns the profile of the logged-in user.
+func (ph *UsersHandler) GetMe(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	profile, err := ph.UsersUsecase.GetProfile(userClaims.UserID)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Success to get profile", "user": profile})
+}
+
+// UpdateMe updates the gender, display name or avatar of the logged-in user.
+func (ph *UsersHandler) UpdateMe(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestUpdateProfile
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(400, gin.H{"error": "Bad request", "message": err.Error()})
+		return
+	}
+
+	profile, err := ph.UsersUsecase.UpdateProfile(userClaims.UserID, request)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Profile updated successfully", "user": profile})
+}
+
+// ChangePassword changes the password of the logged-in user after checking the current one.
+func (ph *UsersHandler) ChangePassword(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestChangePassword
+	if err := c.ShouldBindJSON(&request); err != nil || request.CurrentPassword == "" || request.NewPassword == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "fields current_password and new_password required"})
+		return
+	}
+
+	if err := ph.UsersUsecase.ChangePassword(userClaims.UserID, request.CurrentPassword, request.NewPassword); err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Password changed successfully; other sessions have been logged out"})
+}
+
+// DeleteMe permanently deletes the account of the logged-in user.
+func (ph *UsersHandler) DeleteMe(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestDeleteAccount
+	if err := c.ShouldBindJSON(&request); err != nil || request.Password == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "field password required"})
+		return
+	}
+
+	if err := ph.UsersUsecase.DeleteAccount(userClaims.UserID, request.Password); err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Account deleted successfully"})
+}
+
+// SetupTwoFactor starts TOTP enrolment and returns the secret and provisioning URI for the authenticator app.
+func (ph *UsersHandler) SetupTwoFactor(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	setup, err := ph.UsersUsecase.SetupTwoFactor(userClaims.UserID)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Scan the provisioning URI, then confirm with a code", "two_factor": setup})
+}
+
+// EnableTwoFactor confirms TOTP enrolment with a code and returns the recovery codes.
+func (ph *UsersHandler) EnableTwoFactor(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestTwoFactorCode
+	if err := c.ShouldBindJSON(&request); err != nil || request.Code == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "field code required"})
+		return
+	}
+
+	codes, err := ph.UsersUsecase.EnableTwoFactor(userClaims.UserID, request.Code)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"message":        "Two-factor authentication enabled; store the recovery codes safely and log in again",
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactor turns two-factor authentication off.
+func (ph *UsersHandler) DisableTwoFactor(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestDisableTwoFactor
+	if err := c.ShouldBindJSON(&request); err != nil || request.Password == "" || request.Code == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "fields password and code required"})
+		return
+	}
+
+	if err := ph.UsersUsecase.DisableTwoFactor(userClaims.UserID, request.Password, request.Code); err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the logged-in user.
+func (ph *UsersHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	var request model.RequestTwoFactorCode
+	if err := c.ShouldBindJSON(&request); err != nil || request.Code == "" {
+		c.JSON(400, gin.H{"error": "Bad request", "message": "field code required"})
+		return
+	}
+
+	codes, err := ph.UsersUsecase.RegenerateRecoveryCodes(userClaims.UserID, request.Code)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Recovery codes replaced", "recovery_codes": codes})
+}
+
+// respondProfileError maps self-service account errors to HTTP responses.
+func respondProfileError(c *gin.Context, err error) {
+	switch {
+	case strings.Contains(err.Error(), "user not found"):
+		c.JSON(404, gin.H{"error": "Not Found", "message": "User not found"})
+	case strings.Contains(err.Error(), "invalid password"):
+		c.JSON(401, gin.H{"error": "Unauthorized", "message": "Password is invalid"})
+	case strings.Contains(err.Error(), "cannot delete the last admin"):
+		c.JSON(409, gin.H{"error": "Conflict", "message": "The last admin cannot delete their account"})
+	case strings.Contains(err.Error(), "two-factor already enabled"):
+		c.JSON(409, gin.H{"error": "Conflict", "message": "Two-factor authentication is already enabled"})
+	case strings.Contains(err.Error(), "two-factor setup required"),
+		strings.Contains(err.Error(), "two-factor not enabled"),
+		strings.Contains(err.Error(), "invalid code"):
+		c.JSON(400, gin.H{"error": "Bad request", "message": err.Error()})
+	case strings.Contains(err.Error(), "invalid gender"),
+		strings.Contains(err.Error(), "invalid display name"),
+		strings.Contains(err.Error(), "invalid avatar url"),
+		strings.Contains(err.Error(), "no fields to update"):
+		c.JSON(400, gin.H{"error": "Bad request", "message": err.Error()})
+	default:
+		log.Println("Error updating account: ", err)
+		c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to update account"})
+	}
+}
+
+// ListUsers handles the admin request to list user accounts with pagination.
+func (ph *UsersHandler) ListUsers(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	users, err := ph.UsersUsecase.ListUsers(page, limit)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid page or limit") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// ListRoles handles the admin request to list roles and their permissions.
+func (ph *UsersHandler) ListRoles(c *gin.Context) {
+	roles, err := ph.UsersUsecase.ListRoles()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch roles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+// UpdateUserRole handles the admin request to assign a role to a user.
+func (ph *UsersHandler) UpdateUserRole(c *gin.Context) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
 		return
 	}
 
-	// Validate email and password
-	if err := bcrypt.CompareHashAndPassword([]byte(result.Password), []byte(user.Password)); err != nil {
-		c.JSON(401, gin.H{"error": "Unauthorized", "message": "Email or password is invalid"})
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var request model.RequestUpdateRole
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		return
+	}
+
+	err = ph.UsersUsecase.UpdateRole(userClaims.UserID, userID, request.Role)
+	if err != nil {
+		respondAdminUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role updated successfully"})
+}
+
+// DisableUser handles the admin request to disable a user account.
+func (ph *UsersHandler) DisableUser(c *gin.Context) {
+	ph.setDisabled(c, true, "User disabled successfully")
+}
+
+// EnableUser handles the admin request to re-enable a disabled user account.
+func (ph *UsersHandler) EnableUser(c *gin.Context) {
+	ph.setDisabled(c, false, "User enabled successfully")
+}
+
+func (ph *UsersHandler) setDisabled(c *gin.Context, disabled bool, message string) {
+	userClaims, ok := getUserClaims(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	err = ph.UsersUsecase.SetDisabled(userClaims.UserID, userID, disabled)
+	if err != nil {
+		respondAdminUserError(c, err)
 		return
 	}
 
-	// Create JWT token for successful login
-	expirationTime := time.Now().Add(24 * time.Hour) // Token valid for 24 hours
-	claims := &Claims{
-		UserID: result.Id,
-		Role:   result.Role, // Include role in the token
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-		},
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// ListLockedAccounts handles the admin request to list accounts and client IPs locked out after failed logins.
+func (ph *UsersHandler) ListLockedAccounts(c *gin.Context) {
+	accounts, ips, err := ph.UsersUsecase.ListLockedLogins()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch locked accounts"})
+		return
 	}
 
-	// Sign the JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtSecret)
+	c.JSON(http.StatusOK, gin.H{"accounts": accounts, "ips": ips})
+}
+
+// UnlockUser handles the admin request to lift the login lockout of an account.
+func (ph *UsersHandler) UnlockUser(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := ph.UsersUsecase.UnlockUser(userID); err != nil {
+		respondAdminUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account unlocked"})
+}
+
+// UnlockIP handles the admin request to lift the login lockout of a client IP address.
+func (ph *UsersHandler) UnlockIP(c *gin.Context) {
+	if err := ph.UsersUsecase.UnlockIP(c.Param("ip")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlock IP"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "IP unlocked"})
+}
+
+// respondAdminUserError maps errors of the admin user endpoints to HTTP responses.
+func respondAdminUserError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "invalid role") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown role"})
+	} else if strings.Contains(err.Error(), "cannot change own account") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Admins cannot change their own account"})
+	} else if strings.Contains(err.Error(), "user not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	} else {
+		log.Println("Error updating user: ", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
+	}
+}
+
+// completeLogin finishes a first-factor login. Accounts with two-factor authentication get a short-lived
+// mfa_token for POST /user/login/2fa instead of tokens.
+func completeLogin(c *gin.Context, users *usecase.UsersUseCase, user *model.Users) {
+	if user.TOTPEnabledAt == nil {
+		issueTokens(c, users, user, false)
+		return
+	}
+
+	mfaToken, ttl, err := users.StartTwoFactorLogin(user.Id)
+	if err != nil {
+		log.Println("Error starting two-factor login: ", err)
+		c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to log in"})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"message":      "Two-factor authentication required",
+		"mfa_required": true,
+		"mfa_token":    mfaToken,
+		"expires_in":   int(ttl.Seconds()),
+	})
+}
+
+// issueTokens starts a new login session and returns its access and refresh tokens.
+func issueTokens(c *gin.Context, users *usecase.UsersUseCase, user *model.Users, twoFactor bool) {
+	refreshToken, err := users.CreateRefreshToken(user.Id, twoFactor)
+	if err != nil {
+		log.Println("Error creating refresh token: ", err)
+		c.JSON(500, gin.H{"error": "Internal server error", "message": "Failed to create refresh token"})
+		return
+	}
+
+	respondWithTokens(c, "Login successful", user, refreshToken, twoFactor)
+}
+
+// respondWithTokens signs an access token for the user and returns it with the refresh token.
+func respondWithTokens(c *gin.Context, message string, user *model.Users, refreshToken string, twoFactor bool) {
+	tokenString, err := middleware.SignAccessToken(user.Id, user.Role, twoFactor)
 	if err != nil {
 		log.Println("Error signing token: ", err)
 		c.JSON(500, gin.H{"error": "Internal server error", "message": err.Error()})
@@ -133,5 +643,10 @@ func (ph *UsersHandler) Login(c *gin.Context) {
 	}
 
 	// Return the JWT token to the client
-	c.JSON(200, gin.H{"message": "Login successful", "token": tokenString})
+	c.JSON(200, gin.H{
+		"message":       message,
+		"token":         tokenString,
+		"refresh_token": refreshToken,
+		"expires_in":    int(middleware.AccessTokenTTL().Seconds()),
+	})
 }
@@ -0,0 +1,252 @@
+// Package fakedb is an in-memory database/sql driver for tests. Tests model the tables they need in
+// a Handler; each statement reaches it with its verb, its table and its placeholder values keyed by
+// the column they are compared with or assigned to, so handlers do not depend on the exact SQL text
+// or on the order of the arguments.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Statement is a query or statement sent to a fake database.
+type Statement struct {
+	Verb  string                  // SELECT, INSERT, UPDATE or DELETE
+	Table string                  // First table named after FROM, INTO or UPDATE
+	Query string                  // Full SQL text
+	Args  map[string]driver.Value // Placeholder values by column, or by the keyword INTERVAL, LIMIT or OFFSET
+}
+
+// Mentions reports whether the statement refers to a column or keyword.
+func (s *Statement) Mentions(word string) bool {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`).MatchString(s.Query)
+}
+
+// Int returns the placeholder value bound to a column as an int, or zero when it is missing.
+func (s *Statement) Int(column string) int {
+	value, _ := s.Args[column].(int64)
+	return int(value)
+}
+
+// String returns the placeholder value bound to a column as a string, or "" when it is missing.
+func (s *Statement) String(column string) string {
+	switch value := s.Args[column].(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Result is the answer of a Handler. Queries return Rows; statements report RowsAffected and LastInsertID.
+type Result struct {
+	Rows         [][]driver.Value
+	RowsAffected int64
+	LastInsertID int64
+}
+
+// Handler answers the statements sent to a fake database. A nil Result means no rows and nothing affected.
+type Handler func(stmt *Statement) (*Result, error)
+
+// Open returns a database whose statements are answered by handle, one statement at a time.
+func Open(handle Handler) *sql.DB {
+	return sql.OpenDB(&connector{handle: handle})
+}
+
+var (
+	verbPattern       = regexp.MustCompile(`(?i)^\s*(\w+)`)
+	tablePattern      = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE)\s+(\w+)`)
+	insertPattern     = regexp.MustCompile(`(?is)\bINTO\s+\w+\s*\(([^)]*)\)\s*VALUES\s*\(`)
+	comparisonPattern = regexp.MustCompile(`(?:\w+\.)?(\w+)\s*(?:=|<>|!=|<=|>=|<|>)\s*$`)
+	keywordPattern    = regexp.MustCompile(`(?i)\b(INTERVAL|LIMIT|OFFSET)\s*$`)
+)
+
+// parseStatement splits a query into its verb and table and names its placeholders.
+func parseStatement(query string, args []driver.NamedValue) *Statement {
+	stmt := &Statement{Query: query, Args: make(map[string]driver.Value)}
+	if match := verbPattern.FindStringSubmatch(query); match != nil {
+		stmt.Verb = strings.ToUpper(match[1])
+	}
+	if match := tablePattern.FindStringSubmatch(query); match != nil {
+		stmt.Table = match[1]
+	}
+
+	insertColumns := insertPlaceholders(query)
+	placeholder := 0
+	for i := 0; i < len(query) && placeholder < len(args); i++ {
+		if query[i] != '?' {
+			continue
+		}
+
+		name := insertColumns[i]
+		if name == "" {
+			if match := comparisonPattern.FindStringSubmatch(query[:i]); match != nil {
+				name = strings.ToLower(match[1])
+			} else if match := keywordPattern.FindStringSubmatch(query[:i]); match != nil {
+				name = strings.ToLower(match[1])
+			}
+		}
+
+		// The first placeholder of a column wins, e.g. in "step = ? ... step < ?"
+		if _, bound := stmt.Args[name]; name != "" && !bound {
+			stmt.Args[name] = args[placeholder].Value
+		}
+		placeholder++
+	}
+
+	return stmt
+}
+
+// insertPlaceholders maps the offset of each bare placeholder in the VALUES list of an INSERT to its column.
+func insertPlaceholders(query string) map[int]string {
+	columns := make(map[int]string)
+	match := insertPattern.FindStringSubmatchIndex(query)
+	if match == nil {
+		return columns
+	}
+
+	names := strings.Split(query[match[2]:match[3]], ",")
+	column, depth, start := 0, 0, match[1]
+	for i := match[1]; i < len(query) && depth >= 0; i++ {
+		switch query[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if (query[i] == ',' && depth == 0) || depth < 0 {
+			if value := strings.TrimSpace(query[start:i]); value == "?" && column < len(names) {
+				columns[start+strings.Index(query[start:i], "?")] = strings.ToLower(strings.TrimSpace(names[column]))
+			}
+			column++
+			start = i + 1
+		}
+	}
+
+	return columns
+}
+
+type connector struct {
+	mu     sync.Mutex
+	handle Handler
+}
+
+func (c *connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{connector: c}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+// run answers a statement, serializing the handler like a database serializes conflicting writes.
+func (c *connector) run(query string, args []driver.NamedValue) (*Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, err := c.handle(parseStatement(query, args))
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		result = &Result{}
+	}
+
+	return result, nil
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fakedb: open the database with fakedb.Open")
+}
+
+type conn struct {
+	connector *connector
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fakedb: prepared statements are not supported")
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result, err := c.connector.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rows{rows: result.Rows}, nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result, err := c.connector.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return execResult{affected: result.RowsAffected, lastInsertID: result.LastInsertID}, nil
+}
+
+// tx does not isolate anything; statements apply as soon as the handler answers them.
+type tx struct{}
+
+func (tx) Commit() error   { return nil }
+func (tx) Rollback() error { return nil }
+
+type execResult struct {
+	affected     int64
+	lastInsertID int64
+}
+
+func (r execResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r execResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+type rows struct {
+	rows [][]driver.Value
+}
+
+// Columns names the columns column1, column2, ... since scanning only needs their number.
+func (r *rows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+
+	columns := make([]string, len(r.rows[0]))
+	for i := range columns {
+		columns[i] = fmt.Sprintf("column%d", i+1)
+	}
+	return columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,58 @@
+package fakedb
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestParseStatementNamesPlaceholders(t *testing.T) {
+	tests := []struct {
+		query string
+		args  []driver.Value
+		verb  string
+		table string
+		want  map[string]driver.Value
+	}{
+		{
+			query: "SELECT id, user_id FROM user_tokens WHERE token_hash = ? AND purpose = ? AND expires_at > NOW()",
+			args:  []driver.Value{"hash", "login"},
+			verb:  "SELECT",
+			table: "user_tokens",
+			want:  map[string]driver.Value{"token_hash": "hash", "purpose": "login"},
+		},
+		{
+			query: "INSERT INTO movie_views (session_id, movie_id, viewed_at) VALUES (?, ?, NOW()) ON DUPLICATE KEY UPDATE n = IF(x < NOW() - INTERVAL ? MINUTE, 1, n + 1)",
+			args:  []driver.Value{"s1", int64(5), int64(60)},
+			verb:  "INSERT",
+			table: "movie_views",
+			want:  map[string]driver.Value{"session_id": "s1", "movie_id": int64(5), "interval": int64(60)},
+		},
+		{
+			query: "UPDATE login_throttles SET locked_until = NOW() + INTERVAL ? SECOND WHERE scope = ? AND m.subject = ?",
+			args:  []driver.Value{int64(300), "account", "a@example.com"},
+			verb:  "UPDATE",
+			table: "login_throttles",
+			want:  map[string]driver.Value{"interval": int64(300), "scope": "account", "subject": "a@example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		args := make([]driver.NamedValue, len(test.args))
+		for i, arg := range test.args {
+			args[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+		}
+
+		stmt := parseStatement(test.query, args)
+		if stmt.Verb != test.verb || stmt.Table != test.table {
+			t.Errorf("%q: got %s on %s", test.query, stmt.Verb, stmt.Table)
+		}
+		if len(stmt.Args) != len(test.want) {
+			t.Errorf("%q: got args %v, want %v", test.query, stmt.Args, test.want)
+		}
+		for name, value := range test.want {
+			if stmt.Args[name] != value {
+				t.Errorf("%q: %s = %v, want %v", test.query, name, stmt.Args[name], value)
+			}
+		}
+	}
+}
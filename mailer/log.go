@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// LogMailer writes emails to a file, or to the application log when no path is set.
+// It is meant for local development and testing.
+type LogMailer struct {
+	Path string // File the emails are appended to
+}
+
+// Send records the email instead of delivering it.
+func (m *LogMailer) Send(to, subject, body string) error {
+	entry := fmt.Sprintf("=== %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), to, subject, body)
+
+	if m.Path == "" {
+		log.Print("Mail: ", entry)
+		return nil
+	}
+
+	file, err := os.OpenFile(m.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail log: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(entry)
+	return err
+}
@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"os"
+)
+
+// Mailer sends plain-text emails.
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// NewFromEnv builds the mailer selected by MAILER: "smtp" uses the SMTP_* settings,
+// anything else (the default "log") writes messages to MAIL_LOG_FILE or the application log.
+func NewFromEnv() Mailer {
+	if os.Getenv("MAILER") == "smtp" {
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+
+		return &SMTPMailer{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		}
+	}
+
+	return &LogMailer{Path: os.Getenv("MAIL_LOG_FILE")}
+}
@@ -2,15 +2,31 @@ package main
 
 import (
 	"log"
+	"movies/command"
 	"movies/config"
+	"movies/geo"
 	"movies/handler"
+	"movies/mailer"
+	"movies/middleware"
+	"movies/oidc"
 	"movies/repository"
 	"movies/router"
 	"movies/usecase"
 	"movies/utils"
+	"os"
 )
 
 func main() {
+	// Run a maintenance command instead of the server, e.g. "movies recommend train".
+	// Commands connect to the database themselves when they need it and do not use the server's secrets.
+	if len(os.Args) > 1 {
+		utils.LoadEnvironment()
+		if err := command.Run(os.Args[1:], config.InitMysql); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Load environment variables
 	utils.InitEnvironment()
 
@@ -22,23 +38,101 @@ func main() {
 		return
 	}
 
-	// Set up repository, use case, and handler for movie-related functionality
+	// Load the keys that sign and verify access tokens
+	if err := middleware.LoadKeySet(); err != nil {
+		log.Fatal("Failed to load JWT keys: ", err)
+	}
+
+	// Set up repository, use case, and handler for festival editions and programme sections
+	festivalRepo := repository.NewFestivalRepo(db)
+	festivalUseCase := usecase.NewFestivalUseCase(festivalRepo)
+	festivalHandler := handler.NewFestivalHandler(festivalUseCase)
+
+	// Resolve viewer countries for the online availability rules of movies
+	countryResolver, err := geo.NewFromEnv()
+	if err != nil {
+		log.Fatal("Failed to load GeoIP database: ", err)
+	}
+
+	// Set up repository, use case, and handler for movie availability rules
 	movieRepo := repository.NewMoviesRepo(db)
-	movieUseCase := usecase.NewMoviesUseCase(movieRepo)
+	rolesRepo := repository.NewRolesRepo(db)
+	availabilityRepo := repository.NewAvailabilityRepo(db)
+	availabilityUseCase := usecase.NewAvailabilityUseCase(availabilityRepo, movieRepo, rolesRepo, countryResolver)
+	availabilityHandler := handler.NewAvailabilityHandler(availabilityUseCase)
+
+	// Set up use case and handler for movie-related functionality
+	movieUseCase := usecase.NewMoviesUseCase(movieRepo, festivalRepo, availabilityUseCase)
 	movieHandler := handler.NewMoviesHandler(movieUseCase)
 
-	// Set up repository, use case, and handler for stats-related functionality
+	// Set up repository, use case, and handler for venues and the screening schedule
+	screeningsRepo := repository.NewScreeningsRepo(db)
+	screeningsUseCase := usecase.NewScreeningsUseCase(screeningsRepo, movieRepo)
+	screeningsHandler := handler.NewScreeningsHandler(screeningsUseCase)
+
+	// Set up repository, use case, and handler for seat reservations
+	ticketsRepo := repository.NewTicketsRepo(db)
+	ticketsUseCase := usecase.NewTicketsUseCase(ticketsRepo, screeningsRepo)
+	ticketsHandler := handler.NewTicketsHandler(ticketsUseCase)
+
+	// Set up repository, use case, and handler for abuse scoring and review
+	abuseRepo := repository.NewAbuseRepo(db)
+	abuseUseCase := usecase.NewAbuseUseCase(abuseRepo)
+	abuseHandler := handler.NewAbuseHandler(abuseUseCase)
+
+	// Set up repository, use case, and handler for recommendations
 	statsRepo := repository.NewStatsRepo(db)
-	statsUseCase := usecase.NewStatsUseCase(statsRepo)
+	recommendationsRepo := repository.NewRecommendationsRepo(db)
+	recommendationsUseCase := usecase.NewRecommendationsUseCase(recommendationsRepo, statsRepo, availabilityUseCase)
+	recommendationsHandler := handler.NewRecommendationsHandler(recommendationsUseCase)
+
+	// Set up repository, use case, and handler for audience-award ballots
+	ballotsRepo := repository.NewBallotsRepo(db)
+	ballotsUseCase := usecase.NewBallotsUseCase(ballotsRepo, movieRepo, festivalRepo)
+	ballotsHandler := handler.NewBallotsHandler(ballotsUseCase)
+
+	// Set up repository, use case, and handler for awards and their winners
+	awardsRepo := repository.NewAwardsRepo(db)
+	awardsUseCase := usecase.NewAwardsUseCase(awardsRepo, movieRepo, ballotsUseCase, festivalUseCase)
+	awardsHandler := handler.NewAwardsHandler(awardsUseCase)
+
+	// Set up use case and handler for stats-related functionality
+	statsUseCase := usecase.NewStatsUseCase(statsRepo, abuseUseCase, recommendationsUseCase, festivalUseCase, availabilityUseCase)
 	statsHandler := handler.NewStatsHandler(statsUseCase)
 
 	// Set up repository, use case, and handler for user-related functionality
 	userRepo := repository.NewUsersRepo(db)
-	userUseCase := usecase.NewUsersUseCase(userRepo)
+	tokensRepo := repository.NewTokensRepo(db)
+	loginThrottleRepo := repository.NewLoginThrottleRepo(db)
+	userUseCase := usecase.NewUsersUseCase(userRepo, tokensRepo, rolesRepo, loginThrottleRepo, abuseUseCase, mailer.NewFromEnv())
 	userHandler := handler.NewUsersHandler(userUseCase)
 
+	// Set up repository, use case, and handler for jury panels and scoring
+	juryRepo := repository.NewJuryRepo(db)
+	juryUseCase := usecase.NewJuryUseCase(juryRepo, festivalRepo, userRepo, rolesRepo)
+	juryHandler := handler.NewJuryHandler(juryUseCase)
+
+	// Set up repository, use case, and handler for film submissions
+	submissionsRepo := repository.NewSubmissionsRepo(db)
+	submissionsUseCase := usecase.NewSubmissionsUseCase(submissionsRepo, movieRepo, festivalRepo, userRepo, rolesRepo)
+	submissionsHandler := handler.NewSubmissionsHandler(submissionsUseCase)
+
+	// Set up OIDC login; it stays disabled while OIDC_ISSUER is unset
+	oidcProvider, err := oidc.NewProviderFromEnv()
+	if err != nil {
+		log.Fatal("Invalid OIDC configuration: ", err)
+	}
+	oidcUseCase := usecase.NewOIDCUseCase(oidcProvider, repository.NewOIDCRepo(db), userUseCase)
+	oidcHandler := handler.NewOIDCHandler(oidcUseCase, userUseCase)
+
+	// Let AuthMiddleware reject revoked access tokens and disabled accounts,
+	// and RequirePermission resolve role permissions
+	middleware.SetTokenStore(tokensRepo)
+	middleware.SetUserStore(userRepo)
+	middleware.SetPermissionStore(rolesRepo)
+
 	// Initialize router with handlers
-	r := router.Router(movieHandler, statsHandler, userHandler)
+	r := router.Router(movieHandler, availabilityHandler, statsHandler, userHandler, abuseHandler, recommendationsHandler, oidcHandler, festivalHandler, screeningsHandler, ticketsHandler, juryHandler, awardsHandler, submissionsHandler, ballotsHandler)
 
 	// Start the server on port 9191
 	err = r.Run(":9191")
@@ -1,8 +1,8 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -11,14 +11,14 @@ import (
 
 // Claims represents the structure of the JWT claims
 type Claims struct {
-	UserID int    `json:"id"`   // User ID in the JWT claim
-	Role   string `json:"role"` // User role in the JWT claim
+	UserID    int    `json:"id"`            // User ID in the JWT claim
+	Role      string `json:"role"`          // User role in the JWT claim
+	TwoFactor bool   `json:"mfa,omitempty"` // Whether the login passed the two-factor step
 	jwt.RegisteredClaims
 }
 
 // AuthMiddleware authenticates requests using JWT tokens
 func AuthMiddleware() gin.HandlerFunc {
-	var jwtSecret = []byte(os.Getenv("JWT_SECRET")) // Secret key for JWT
 	return func(c *gin.Context) {
 		// Retrieve the Authorization header
 		tokenString := c.GetHeader("Authorization")
@@ -28,25 +28,9 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Parse the token format (e.g., "Bearer <token>")
-		parts := strings.Split(tokenString, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
-			c.Abort()
-			return
-		}
-
-		// Extract the token
-		tokenString = parts[1]
-
-		// Parse and validate the token
-		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return jwtSecret, nil
-		})
-
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		claims, status, message := parseAuthorization(tokenString)
+		if claims == nil {
+			c.JSON(status, gin.H{"error": message})
 			c.Abort()
 			return
 		}
@@ -57,35 +41,73 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
-// RoleMiddleware restricts access to users with specific roles
-func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
+// OptionalAuthMiddleware authenticates requests that carry a JWT token and lets anonymous requests through.
+// A token that is present but invalid is still rejected.
+func OptionalAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Retrieve claims from the context
-		claims, exists := c.Get("claims")
-		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Missing claims"})
-			c.Abort()
+		tokenString := c.GetHeader("Authorization")
+		if tokenString == "" {
+			c.Next()
 			return
 		}
 
-		// Assert claims to the expected type
-		userClaims, ok := claims.(*Claims)
-		if !ok {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid claims"})
+		claims, status, message := parseAuthorization(tokenString)
+		if claims == nil {
+			c.JSON(status, gin.H{"error": message})
 			c.Abort()
 			return
 		}
 
-		// Check if the user's role is in the allowed roles
-		for _, role := range allowedRoles {
-			if userClaims.Role == role {
-				c.Next()
-				return
-			}
+		// Store claims in the context for further use
+		c.Set("claims", claims)
+		c.Next()
+	}
+}
+
+// parseAuthorization validates an Authorization header value and returns its claims.
+// When the token is rejected the claims are nil and the status and message describe the reason.
+func parseAuthorization(header string) (*Claims, int, string) {
+	if keySet == nil {
+		return nil, http.StatusInternalServerError, "Failed to validate token"
+	}
+
+	// Parse the token format (e.g., "Bearer <token>")
+	parts := strings.Split(header, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, http.StatusUnauthorized, "Invalid Authorization header format"
+	}
+
+	// Parse and validate the token, accepting only the configured algorithm
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(parts[1], claims, keySet.keyFunc, jwt.WithValidMethods([]string{keySet.alg}))
+
+	if err != nil || !token.Valid {
+		return nil, http.StatusUnauthorized, "Invalid token"
+	}
+
+	// Reject tokens revoked by logout
+	if tokenStore != nil && claims.ID != "" {
+		revoked, err := tokenStore.IsTokenRevoked(claims.ID)
+		if err != nil {
+			log.Println("ERR check token revocation: ", err)
+			return nil, http.StatusInternalServerError, "Failed to validate token"
+		}
+		if revoked {
+			return nil, http.StatusUnauthorized, "Token revoked"
 		}
+	}
 
-		// If no match, respond with forbidden
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		c.Abort()
+	// Reject tokens of accounts disabled by an admin
+	if userStore != nil {
+		disabled, err := userStore.IsUserDisabled(claims.UserID)
+		if err != nil {
+			log.Println("ERR check user status: ", err)
+			return nil, http.StatusInternalServerError, "Failed to validate token"
+		}
+		if disabled {
+			return nil, http.StatusForbidden, "Account disabled"
+		}
 	}
+
+	return claims, 0, ""
 }
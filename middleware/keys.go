@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported access token signing algorithms, selected by JWT_ALG.
+const (
+	AlgHS256 = "HS256" // Shared JWT_SECRET; tokens cannot be verified by other services
+	AlgRS256 = "RS256" // RSA keys from JWT_KEYS_DIR
+	AlgEdDSA = "EdDSA" // Ed25519 keys from JWT_KEYS_DIR
+)
+
+// signingKey is one key of the key set. Retired keys only have a public half and are used for verification only.
+type signingKey struct {
+	kid     string
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeySet holds the keys used to sign and verify access tokens.
+type KeySet struct {
+	alg       string
+	method    jwt.SigningMethod
+	secret    []byte                 // HS256 only
+	keys      map[string]*signingKey // Asymmetric keys by kid
+	activeKid string                 // Key used to sign new tokens
+}
+
+// keySet is used by SignAccessToken and AuthMiddleware; it is loaded at startup by LoadKeySet.
+var keySet *KeySet
+
+// LoadKeySet loads the signing keys configured by JWT_ALG, JWT_KEYS_DIR and JWT_ACTIVE_KID.
+//
+// For RS256 and EdDSA every "<kid>.pem" file in JWT_KEYS_DIR (default "keys") is trusted for verification.
+// Files holding a private key can sign; files holding only a public key are retired keys kept until the
+// tokens they signed expire. New tokens are signed with JWT_ACTIVE_KID, or the last private key by name.
+func LoadKeySet() error {
+	alg := os.Getenv("JWT_ALG")
+	if alg == "" {
+		alg = AlgHS256
+	}
+
+	switch alg {
+	case AlgHS256:
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return fmt.Errorf("JWT_SECRET is required for %s", AlgHS256)
+		}
+		keySet = &KeySet{alg: alg, method: jwt.SigningMethodHS256, secret: []byte(secret)}
+		return nil
+	case AlgRS256, AlgEdDSA:
+	default:
+		return fmt.Errorf("unsupported JWT_ALG %q", alg)
+	}
+
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		dir = "keys"
+	}
+
+	keys, err := loadKeyDir(dir, alg)
+	if err != nil {
+		return err
+	}
+
+	activeKid := os.Getenv("JWT_ACTIVE_KID")
+	if activeKid == "" {
+		activeKid = lastPrivateKid(keys)
+	}
+	active, ok := keys[activeKid]
+	if !ok || active.private == nil {
+		return fmt.Errorf("no private key for active kid %q in %s", activeKid, dir)
+	}
+
+	keySet = &KeySet{alg: alg, method: jwt.GetSigningMethod(alg), keys: keys, activeKid: activeKid}
+	return nil
+}
+
+// loadKeyDir reads every PEM key in dir, rejecting keys that do not match the algorithm.
+func loadKeyDir(dir, alg string) (map[string]*signingKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*signingKey)
+	for _, path := range paths {
+		kid := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+
+		key, err := parseKeyFile(path, alg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", path, err)
+		}
+		key.kid = kid
+
+		// A private key takes precedence over a public copy of the same kid
+		if existing, ok := keys[kid]; ok && existing.private != nil {
+			continue
+		}
+		keys[kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys found in %s; run \"movies keys generate\"", dir)
+	}
+
+	return keys, nil
+}
+
+// parseKeyFile parses a PKCS#8 private key or PKIX public key and checks it matches the algorithm.
+func parseKeyFile(path, alg string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data")
+	}
+
+	key := &signingKey{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key")
+		}
+		key.private = signer
+		key.public = signer.Public()
+	case "PUBLIC KEY":
+		key.public, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	switch key.public.(type) {
+	case *rsa.PublicKey:
+		if alg != AlgRS256 {
+			return nil, fmt.Errorf("RSA key cannot be used with %s", alg)
+		}
+	case ed25519.PublicKey:
+		if alg != AlgEdDSA {
+			return nil, fmt.Errorf("Ed25519 key cannot be used with %s", alg)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key.public)
+	}
+
+	return key, nil
+}
+
+// lastPrivateKid returns the last kid by name that has a private key; generated kids start with their creation date.
+func lastPrivateKid(keys map[string]*signingKey) string {
+	var kids []string
+	for kid, key := range keys {
+		if key.private != nil {
+			kids = append(kids, kid)
+		}
+	}
+	if len(kids) == 0 {
+		return ""
+	}
+
+	sort.Strings(kids)
+	return kids[len(kids)-1]
+}
+
+// sign signs the claims with the active key, setting the kid header for asymmetric keys.
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.method, claims)
+	if ks.alg == AlgHS256 {
+		return token.SignedString(ks.secret)
+	}
+
+	token.Header["kid"] = ks.activeKid
+	return token.SignedString(ks.keys[ks.activeKid].private)
+}
+
+// keyFunc returns the verification key of a token. Only the configured algorithm is accepted,
+// and asymmetric tokens must name a known kid.
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != ks.alg {
+		return nil, fmt.Errorf("unexpected signing method %q", token.Method.Alg())
+	}
+
+	if ks.alg == AlgHS256 {
+		return ks.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+
+	return key.public, nil
+}
+
+// JWK is a public key in JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`           // Key type: "RSA" or "OKP"
+	Use string `json:"use"`           // Always "sig"
+	Alg string `json:"alg"`           // Signing algorithm
+	Kid string `json:"kid"`           // Key ID matching the token header
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // Curve name for OKP keys
+	X   string `json:"x,omitempty"`   // Ed25519 public key
+}
+
+// PublicJWKS returns the public keys that verify access tokens, including retired keys.
+// The list is empty for HS256, whose shared secret is never published.
+func PublicJWKS() []JWK {
+	jwks := []JWK{}
+	if keySet == nil {
+		return jwks
+	}
+
+	kids := make([]string, 0, len(keySet.keys))
+	for kid := range keySet.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	for _, kid := range kids {
+		jwk := JWK{Use: "sig", Alg: keySet.alg, Kid: kid}
+		switch public := keySet.keys[kid].public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks = append(jwks, jwk)
+	}
+
+	return jwks
+}
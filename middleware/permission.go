@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionStore resolves which permissions a role grants.
+type PermissionStore interface {
+	RoleHasPermission(role, permission string) (bool, error)
+}
+
+// permissionStore is consulted by RequirePermission; every permission check fails while it is nil.
+var permissionStore PermissionStore
+
+// SetPermissionStore registers the store used to resolve role permissions.
+func SetPermissionStore(store PermissionStore) {
+	permissionStore = store
+}
+
+// RequirePermission restricts access to users whose role grants the given permission, e.g. "movies:write".
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Retrieve claims from the context
+		claims, exists := c.Get("claims")
+		if !exists {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Missing claims"})
+			c.Abort()
+			return
+		}
+
+		// Assert claims to the expected type
+		userClaims, ok := claims.(*Claims)
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid claims"})
+			c.Abort()
+			return
+		}
+
+		// Admin tokens without the second factor are refused when REQUIRE_ADMIN_2FA is on
+		if twoFactorMissing(userClaims) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication required"})
+			c.Abort()
+			return
+		}
+
+		if permissionStore == nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			c.Abort()
+			return
+		}
+
+		// Check if the user's role grants the permission
+		granted, err := permissionStore.RoleHasPermission(userClaims.Role, permission)
+		if err != nil {
+			log.Println("ERR check permission: ", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			c.Abort()
+			return
+		}
+		if !granted {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// twoFactorMissing reports whether REQUIRE_ADMIN_2FA is on and an admin token was issued without the second factor.
+func twoFactorMissing(claims *Claims) bool {
+	return claims.Role == "admin" && !claims.TwoFactor && os.Getenv("REQUIRE_ADMIN_2FA") == "true"
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"movies/utils"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenStore reports whether an access token has been revoked before its expiry.
+type TokenStore interface {
+	IsTokenRevoked(jti string) (bool, error)
+}
+
+// tokenStore is consulted by AuthMiddleware; revocation checks are skipped while it is nil.
+var tokenStore TokenStore
+
+// SetTokenStore registers the store used to look up revoked access tokens.
+func SetTokenStore(store TokenStore) {
+	tokenStore = store
+}
+
+// UserStore reports whether a user account has been disabled.
+type UserStore interface {
+	IsUserDisabled(userID int) (bool, error)
+}
+
+// userStore is consulted by AuthMiddleware; account checks are skipped while it is nil.
+var userStore UserStore
+
+// SetUserStore registers the store used to reject tokens of disabled accounts.
+func SetUserStore(store UserStore) {
+	userStore = store
+}
+
+// AccessTokenTTL returns the lifetime of access tokens, configured by ACCESS_TOKEN_TTL_MINUTES (default 15).
+func AccessTokenTTL() time.Duration {
+	return time.Duration(utils.GetEnvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute
+}
+
+// SignAccessToken issues a signed access token for a user with a unique token ID (jti).
+// twoFactor records whether the login passed the two-factor step.
+func SignAccessToken(userID int, role string, twoFactor bool) (string, error) {
+	if keySet == nil {
+		return "", fmt.Errorf("signing keys not loaded")
+	}
+
+	jti, err := utils.GenerateToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	claims := &Claims{
+		UserID:    userID,
+		Role:      role, // Include role in the token
+		TwoFactor: twoFactor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL())),
+		},
+	}
+
+	// Sign the JWT token with the active key
+	return keySet.sign(claims)
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"log"
+	"movies/utils"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	visitorCookieName = "visitor_id"   // Cookie carrying the signed visitor ID
+	visitorHeaderName = "X-Visitor-Id" // Header alternative for clients without cookies
+	visitorCookieAge  = 365 * 24 * 60 * 60
+)
+
+// VisitorMiddleware assigns a signed visitor ID to anonymous requests.
+// Authenticated requests are left untouched; anonymous ones get "visitor_id" set in the context
+// and receive the signed ID back as a cookie and response header.
+func VisitorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Registered users are identified by their token
+		if _, exists := c.Get("claims"); exists {
+			c.Next()
+			return
+		}
+
+		secret := visitorSecret()
+
+		// Reuse a visitor ID from the cookie or header when its signature is valid
+		signed, err := c.Cookie(visitorCookieName)
+		if err != nil || signed == "" {
+			signed = c.GetHeader(visitorHeaderName)
+		}
+		visitorID, ok := utils.VerifySignedValue(secret, signed)
+
+		// Otherwise issue a new visitor ID
+		if !ok {
+			visitorID, err = utils.GenerateToken(16)
+			if err != nil {
+				log.Println("ERR generate visitor id: ", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to identify visitor"})
+				c.Abort()
+				return
+			}
+			signed = utils.SignValue(secret, visitorID)
+		}
+
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(visitorCookieName, signed, visitorCookieAge, "/", "", false, true)
+		c.Header(visitorHeaderName, signed)
+
+		c.Set("visitor_id", visitorID)
+		c.Next()
+	}
+}
+
+// visitorSecret returns the key used to sign visitor IDs, falling back to JWT_SECRET.
+func visitorSecret() []byte {
+	if secret := os.Getenv("VISITOR_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+
+	return []byte(os.Getenv("JWT_SECRET"))
+}
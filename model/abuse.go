@@ -0,0 +1,21 @@
+package model
+
+// AbuseFlag represents a suspicious view, vote, or registration awaiting review.
+type AbuseFlag struct {
+	ID         int     `json:"id"`                    // Flag ID
+	EventType  string  `json:"event_type"`            // Flagged event type (view, vote, registration)
+	EventID    int     `json:"event_id"`              // ID of the flagged movie_views, user_votes, or users row
+	UserID     *int    `json:"user_id,omitempty"`     // User behind the event, if known
+	IPAddress  string  `json:"ip_address,omitempty"`  // Client IP address of the event
+	Score      int     `json:"score"`                 // Abuse score that triggered the flag
+	Reason     string  `json:"reason"`                // Rules that were triggered
+	Status     string  `json:"status"`                // Review status (open, confirmed, dismissed)
+	CreatedAt  string  `json:"created_at"`            // Timestamp the flag was raised
+	ReviewedBy *int    `json:"reviewed_by,omitempty"` // Admin who reviewed the flag
+	ReviewedAt *string `json:"reviewed_at,omitempty"` // Timestamp of the review
+}
+
+// RequestReviewFlag is the payload of an admin review decision.
+type RequestReviewFlag struct {
+	Status string `json:"status"` // confirmed or dismissed
+}
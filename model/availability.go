@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// MovieAvailability holds the online availability rules of a movie.
+type MovieAvailability struct {
+	MovieID        int        `json:"movie_id"`        // Movie ID
+	AvailableFrom  *time.Time `json:"available_from"`  // Start of the window, null for no start
+	AvailableUntil *time.Time `json:"available_until"` // End of the window, null for no end
+	Countries      []string   `json:"countries"`       // Allowed ISO 3166-1 alpha-2 country codes, empty for every country
+	MaxViewers     *int       `json:"max_viewers"`     // Maximum concurrent viewers, null for no limit
+}
+
+// RequestMovieAvailability is the payload to set the availability rules of a movie.
+type RequestMovieAvailability struct {
+	AvailableFrom  *time.Time `json:"available_from"`  // Start of the window
+	AvailableUntil *time.Time `json:"available_until"` // End of the window
+	Countries      []string   `json:"countries"`       // Allowed country codes
+	MaxViewers     *int       `json:"max_viewers"`     // Maximum concurrent viewers
+}
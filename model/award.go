@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// Award kinds. Winners of jury awards are chosen by hand, audience awards are computed from votes.
+const (
+	AwardKindJury     = "jury"
+	AwardKindAudience = "audience"
+)
+
+// AwardCategory represents an award of a festival edition.
+type AwardCategory struct {
+	ID          int               `json:"id"`           // Category ID
+	EditionID   int               `json:"edition_id"`   // Edition the award belongs to
+	Name        string            `json:"name"`         // Award name, e.g. "Best Film"
+	Description string            `json:"description"`  // Award description
+	Kind        string            `json:"kind"`         // jury or audience
+	PublishedAt *time.Time        `json:"published_at"` // Time the winners are (or will be) announced, null while unscheduled
+	Published   bool              `json:"published"`    // Whether the winners are public
+	Nominations []AwardNomination `json:"nominations"`  // Nominated movies
+}
+
+// AwardNomination is a movie nominated for an award.
+type AwardNomination struct {
+	MovieID  int    `json:"movie_id"`  // Movie ID
+	Title    string `json:"title"`     // Movie title
+	Citation string `json:"citation"`  // Jury citation or note
+	IsWinner bool   `json:"is_winner"` // Whether the movie won, always false before publication on public listings
+}
+
+// RequestAwardCategory is the payload to create or update an award category.
+type RequestAwardCategory struct {
+	EditionID   int    `json:"edition_id"`              // Edition of the award, required on creation
+	Name        string `json:"name" binding:"required"` // Award name
+	Description string `json:"description"`             // Award description
+	Kind        string `json:"kind"`                    // jury (default) or audience
+}
+
+// RequestNomination is the payload to nominate a movie for an award.
+type RequestNomination struct {
+	MovieID  int    `json:"movie_id" binding:"required"` // Movie to nominate
+	Citation string `json:"citation"`                    // Jury citation or note
+}
+
+// RequestAwardWinners is the payload to choose the winners of an award among its nominees.
+type RequestAwardWinners struct {
+	MovieIDs []int `json:"movie_ids" binding:"required"` // Winning movies, several for ex aequo awards
+}
+
+// RequestPublishAward is the payload to schedule the announcement of an award's winners.
+type RequestPublishAward struct {
+	PublishedAt *time.Time `json:"published_at"` // Announcement time, now when omitted
+}
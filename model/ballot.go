@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// Ballot sources: the proof that the voter attended the movie.
+const (
+	BallotSourceScreening = "screening"
+	BallotSourceOnline    = "online"
+)
+
+// Ballot is an attendee's audience-award ballot for a movie.
+type Ballot struct {
+	ID        int       `json:"id"`         // Ballot ID
+	MovieID   int       `json:"movie_id"`   // Movie voted for
+	Title     string    `json:"title"`      // Movie title
+	Score     int       `json:"score"`      // Score from 1 to 5
+	Source    string    `json:"source"`     // screening or online
+	CreatedAt time.Time `json:"created_at"` // Timestamp of the ballot
+}
+
+// BallotTallyMovie is the audience score of one movie.
+type BallotTallyMovie struct {
+	MovieID          int     `json:"movie_id"`          // Movie ID
+	Title            string  `json:"title"`             // Movie title
+	Ballots          int     `json:"ballots"`           // Ballots counted
+	ScreeningBallots int     `json:"screening_ballots"` // Ballots of screening attendees
+	OnlineBallots    int     `json:"online_ballots"`    // Ballots of online viewers
+	AverageScore     float64 `json:"average_score"`     // Average score, rounded to 2 decimals
+	Eligible         bool    `json:"eligible"`          // Whether the movie has enough ballots for the audience award
+}
+
+// BallotTally is the audience-award tally of an edition, best average score first.
+type BallotTally struct {
+	EditionID  int                `json:"edition_id"`  // Tallied edition
+	MinBallots int                `json:"min_ballots"` // Ballots a movie needs to be eligible
+	Movies     []BallotTallyMovie `json:"movies"`      // Movies with at least one ballot
+}
+
+// RequestBallot is the payload of an audience-award ballot.
+type RequestBallot struct {
+	Score int `json:"score" binding:"required"` // Score from 1 to 5
+}
@@ -0,0 +1,35 @@
+package model
+
+// FestivalEdition represents one yearly edition of the festival.
+type FestivalEdition struct {
+	ID          int                `json:"id"`                 // Edition ID
+	Year        int                `json:"year"`               // Festival year
+	Theme       string             `json:"theme"`              // Theme of the edition
+	StartsOn    string             `json:"starts_on"`          // First festival day (YYYY-MM-DD)
+	EndsOn      string             `json:"ends_on"`            // Last festival day (YYYY-MM-DD)
+	BlindReview bool               `json:"blind_review"`       // Whether filmmaker identities are hidden from submission reviewers
+	Sections    []ProgrammeSection `json:"sections,omitempty"` // Programme sections, included on detail requests
+}
+
+// ProgrammeSection represents a section of an edition's programme, e.g. Competition or Shorts.
+type ProgrammeSection struct {
+	ID          int    `json:"id"`          // Section ID
+	EditionID   int    `json:"edition_id"`  // Edition the section belongs to
+	Name        string `json:"name"`        // Section name
+	Description string `json:"description"` // Section description
+}
+
+// RequestEdition is the payload to create or update a festival edition.
+type RequestEdition struct {
+	Year        int    `json:"year" binding:"required"`      // Festival year
+	Theme       string `json:"theme"`                        // Theme of the edition
+	StartsOn    string `json:"starts_on" binding:"required"` // First festival day (YYYY-MM-DD)
+	EndsOn      string `json:"ends_on" binding:"required"`   // Last festival day (YYYY-MM-DD)
+	BlindReview bool   `json:"blind_review"`                 // Hide filmmaker identities from submission reviewers
+}
+
+// RequestSection is the payload to create or update a programme section.
+type RequestSection struct {
+	Name        string `json:"name" binding:"required"` // Section name
+	Description string `json:"description"`             // Section description
+}
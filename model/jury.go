@@ -0,0 +1,91 @@
+package model
+
+import "time"
+
+// JuryPanel represents the jury of a programme section.
+type JuryPanel struct {
+	ID          int             `json:"id"`                     // Panel ID
+	SectionID   int             `json:"section_id"`             // Programme section judged by the panel
+	SectionName string          `json:"section_name"`           // Name of the section
+	EditionID   int             `json:"edition_id"`             // Edition of the section
+	Name        string          `json:"name"`                   // Panel name, e.g. "Main Competition Jury"
+	LockedAt    *time.Time      `json:"locked_at"`              // Time the scores were finalized, null while open
+	IsPresident *bool           `json:"is_president,omitempty"` // Whether the caller presides the panel, on juror listings
+	Members     []JuryMember    `json:"members,omitempty"`      // Jurors, included on detail requests
+	Criteria    []JuryCriterion `json:"criteria,omitempty"`     // Scoring criteria, included on detail requests
+	Movies      []JuryMovie     `json:"movies,omitempty"`       // Movies of the section, included on detail requests
+}
+
+// JuryMember represents a juror of a panel.
+type JuryMember struct {
+	UserID      int    `json:"user_id"`      // Juror's user ID
+	DisplayName string `json:"display_name"` // Juror's display name
+	IsPresident bool   `json:"is_president"` // Whether the juror presides the panel
+}
+
+// JuryCriterion represents a criterion a panel scores movies on.
+type JuryCriterion struct {
+	ID      int    `json:"id"`       // Criterion ID
+	PanelID int    `json:"panel_id"` // Panel the criterion belongs to
+	Name    string `json:"name"`     // Criterion name, e.g. "Direction"
+	Weight  int    `json:"weight"`   // Share of the criterion in the ranking
+}
+
+// JuryMovie is a movie a panel judges.
+type JuryMovie struct {
+	ID    int    `json:"id"`    // Movie ID
+	Title string `json:"title"` // Movie title
+}
+
+// JuryScore is a juror's score for a movie on one criterion.
+type JuryScore struct {
+	MovieID     int       `json:"movie_id"`     // Movie scored
+	CriterionID int       `json:"criterion_id"` // Criterion scored
+	Score       int       `json:"score"`        // Score from 1 to 10
+	UpdatedAt   time.Time `json:"updated_at"`   // Time of the last change
+}
+
+// JuryRanking is a movie's place in a panel's aggregate ranking.
+type JuryRanking struct {
+	Rank     int                    `json:"rank"`     // Position in the ranking, ties share a rank
+	MovieID  int                    `json:"movie_id"` // Movie ID
+	Title    string                 `json:"title"`    // Movie title
+	Score    float64                `json:"score"`    // Weighted average of all scores
+	Jurors   int                    `json:"jurors"`   // Jurors who scored the movie
+	Criteria []JuryCriterionAverage `json:"criteria"` // Average per criterion
+}
+
+// JuryCriterionAverage is the average score of a movie on one criterion.
+type JuryCriterionAverage struct {
+	CriterionID int     `json:"criterion_id"` // Criterion ID
+	Name        string  `json:"name"`         // Criterion name
+	Average     float64 `json:"average"`      // Average score of the jurors
+}
+
+// RequestJuryPanel is the payload to create a jury panel.
+type RequestJuryPanel struct {
+	SectionID int    `json:"section_id" binding:"required"` // Programme section to judge
+	Name      string `json:"name" binding:"required"`       // Panel name
+}
+
+// RequestJuryMember is the payload to add a juror to a panel or change their presidency.
+type RequestJuryMember struct {
+	IsPresident bool `json:"is_president"` // Make the juror the panel's president
+}
+
+// RequestJuryCriterion is the payload to add a scoring criterion to a panel.
+type RequestJuryCriterion struct {
+	Name   string `json:"name" binding:"required"` // Criterion name
+	Weight int    `json:"weight"`                  // Share in the ranking, 1 when omitted
+}
+
+// RequestJuryScores is the payload of a juror's scores for one movie.
+type RequestJuryScores struct {
+	Scores []JuryScoreInput `json:"scores" binding:"required"` // Scores per criterion
+}
+
+// JuryScoreInput is a score for one criterion.
+type JuryScoreInput struct {
+	CriterionID int `json:"criterion_id"` // Criterion scored
+	Score       int `json:"score"`        // Score from 1 to 10
+}
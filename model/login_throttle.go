@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Login throttle scopes.
+const (
+	LoginScopeAccount = "account" // Keyed by the lowercased email
+	LoginScopeIP      = "ip"      // Keyed by the client IP address
+)
+
+// LoginThrottle tracks failed login attempts for an account or a client IP.
+type LoginThrottle struct {
+	Scope        string     `json:"scope"`                  // "account" or "ip"
+	Subject      string     `json:"subject"`                // Email or IP address
+	UserID       *int       `json:"user_id,omitempty"`      // Account ID when the email is registered
+	FailedCount  int        `json:"failed_count"`           // Consecutive failed attempts
+	LastFailedAt time.Time  `json:"last_failed_at"`         // Time of the latest failure
+	LockedUntil  *time.Time `json:"locked_until,omitempty"` // End of the current lockout
+}
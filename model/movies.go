@@ -2,11 +2,13 @@ package model
 
 // Movies represents a movie record.
 type Movies struct {
-	ID          int    `json:"id"`                 // Movie ID
-	Title       string `json:"title"`              // Movie title
-	Description string `json:"description"`        // Movie description
-	Duration    string `json:"duration"`           // Movie duration
-	Artist      string `json:"artist"`             // Main artist (actor/director)
-	GenreID     *int   `json:"genre_id,omitempty"` // Genre ID (nullable)
-	WatchURL    string `json:"watch_url"`          // URL to watch the movie
+	ID          int    `json:"id"`                   // Movie ID
+	Title       string `json:"title"`                // Movie title
+	Description string `json:"description"`          // Movie description
+	Duration    string `json:"duration"`             // Movie duration
+	Artist      string `json:"artist"`               // Main artist (actor/director)
+	GenreID     *int   `json:"genre_id,omitempty"`   // Genre ID (nullable)
+	WatchURL    string `json:"watch_url"`            // URL to watch the movie
+	EditionID   *int   `json:"edition_id,omitempty"` // Festival edition ID (nullable)
+	SectionID   *int   `json:"section_id,omitempty"` // Programme section ID (nullable)
 }
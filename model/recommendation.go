@@ -0,0 +1,22 @@
+package model
+
+// RecommendedMovie is a movie suggested to a user together with its relevance score.
+type RecommendedMovie struct {
+	Movies
+	Score  float64 `json:"score"`  // Relevance score, higher is better
+	Source string  `json:"source"` // Model that produced the suggestion (collaborative, content, popular)
+}
+
+// MovieSimilarity is a trained item-item similarity between two movies.
+type MovieSimilarity struct {
+	MovieID        int     `json:"movie_id"`         // Movie ID
+	SimilarMovieID int     `json:"similar_movie_id"` // Neighbouring movie ID
+	Score          float64 `json:"score"`            // Cosine similarity of the two movies' audiences
+}
+
+// UserInteraction is an implicit rating of a movie derived from a user's views and votes.
+type UserInteraction struct {
+	UserID  int     // User ID
+	MovieID int     // Movie ID
+	Rating  float64 // Implicit rating
+}
@@ -0,0 +1,8 @@
+package model
+
+// Role is a named bundle of permissions assigned to users.
+type Role struct {
+	Name        string   `json:"name"`        // Role name stored on users.role
+	Description string   `json:"description"` // Human readable description
+	Permissions []string `json:"permissions"` // Permissions granted by the role
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// Venue represents a physical location where screenings take place.
+type Venue struct {
+	ID       int    `json:"id"`       // Venue ID
+	Name     string `json:"name"`     // Venue name
+	Capacity int    `json:"capacity"` // Number of seats
+	Address  string `json:"address"`  // Street address
+}
+
+// Screening represents a scheduled showing of a movie at a venue.
+type Screening struct {
+	ID         int       `json:"id"`          // Screening ID
+	MovieID    int       `json:"movie_id"`    // Movie shown
+	MovieTitle string    `json:"movie_title"` // Title of the movie shown
+	VenueID    int       `json:"venue_id"`    // Venue of the screening
+	VenueName  string    `json:"venue_name"`  // Name of the venue
+	StartsAt   time.Time `json:"starts_at"`   // Start time
+	EndsAt     time.Time `json:"ends_at"`     // End time, from the movie's running time
+	Language   string    `json:"language"`    // Spoken language of the print
+	Subtitles  string    `json:"subtitles"`   // Subtitle language, empty when none
+}
+
+// RequestVenue is the payload to create or update a venue.
+type RequestVenue struct {
+	Name     string `json:"name" binding:"required"`     // Venue name
+	Capacity int    `json:"capacity" binding:"required"` // Number of seats
+	Address  string `json:"address"`                     // Street address
+}
+
+// RequestScreening is the payload to create or update a screening.
+type RequestScreening struct {
+	MovieID   int       `json:"movie_id" binding:"required"`  // Movie shown
+	VenueID   int       `json:"venue_id" binding:"required"`  // Venue of the screening
+	StartsAt  time.Time `json:"starts_at" binding:"required"` // Start time (RFC 3339)
+	Language  string    `json:"language"`                     // Spoken language of the print
+	Subtitles string    `json:"subtitles"`                    // Subtitle language
+}
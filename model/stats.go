@@ -2,9 +2,12 @@ package model
 
 // MovieStatsView represents statistics for a movie.
 type MovieStatsView struct {
-	ID    int    `json:"id"`    // Movie ID
-	Title string `json:"title"` // Movie Title
-	Views int    `json:"views"` // Number of Views
+	ID              int    `json:"id"`               // Movie ID
+	Title           string `json:"title"`            // Movie Title
+	Plays           int    `json:"plays"`            // Number of playback sessions
+	UniqueViewers   int    `json:"unique_viewers"`   // Number of distinct viewers
+	RegisteredViews int    `json:"registered_views"` // Playback sessions by registered users
+	AnonymousViews  int    `json:"anonymous_views"`  // Playback sessions by anonymous visitors
 }
 
 // MovieVotesView represents vote statistics for a movie.
@@ -16,27 +19,39 @@ type MovieStatsVote struct {
 
 // GenreStats represents statistics for a genre.
 type GenreStats struct {
-	ID    int    `json:"id"`    // Genre ID
-	Name  string `json:"name"`  // Genre Name
-	Views int    `json:"views"` // Number of Views
+	ID              int    `json:"id"`               // Genre ID
+	Name            string `json:"name"`             // Genre Name
+	Plays           int    `json:"plays"`            // Number of playback sessions
+	UniqueViewers   int    `json:"unique_viewers"`   // Number of distinct viewers
+	RegisteredViews int    `json:"registered_views"` // Playback sessions by registered users
+	AnonymousViews  int    `json:"anonymous_views"`  // Playback sessions by anonymous visitors
 }
 
 // StatsModel summarizes movie and genre statistics.
 type StatsModelView struct {
+	EditionID       *int             `json:"edition_id"`        // Edition the statistics cover, null for all editions
 	MostViewedMovie []MovieStatsView `json:"most_viewed_movie"` // Most Viewed Movie
 	MostViewedGenre []GenreStats     `json:"most_viewed_genre"` // Most Viewed Genre
 }
 
 type StatsModelVote struct {
+	EditionID       *int             `json:"edition_id"`        // Edition the statistics cover, null for all editions
 	MostVotedMovie  []MovieStatsVote `json:"most_voted_movie"`  // Most Vote Movie
 	MostViewedGenre []GenreStats     `json:"most_viewed_genre"` // Most Viewed Genre
 }
 
-// MovieView represents a record of a movie viewed by a user.
+// MovieView represents a single playback session of a movie by a user or anonymous visitor.
 type MovieView struct {
-	MovieID  int    `json:"movie_id"`  // Movie ID
-	UserID   int    `json:"user_id"`   // User ID
-	ViewedAt string `json:"viewed_at"` // Timestamp of the view
+	ID              int     `json:"id"`                          // View ID
+	SessionID       string  `json:"session_id"`                  // Playback session identifier
+	MovieID         int     `json:"movie_id"`                    // Movie ID
+	UserID          int     `json:"user_id,omitempty"`           // User ID, zero for anonymous visitors
+	VisitorID       string  `json:"visitor_id,omitempty"`        // Visitor ID of an anonymous session
+	ViewedAt        string  `json:"viewed_at"`                   // Timestamp the session started
+	LastHeartbeatAt *string `json:"last_heartbeat_at,omitempty"` // Timestamp of the latest heartbeat
+	EndedAt         *string `json:"ended_at,omitempty"`          // Timestamp the session ended
+	Duration        int     `json:"duration"`                    // Seconds watched in this session
+	IPAddress       string  `json:"-"`                           // Client IP address, used for abuse scoring
 }
 
 // UserVotedMovie represents a user's vote on a movie.
@@ -0,0 +1,108 @@
+package model
+
+import "time"
+
+// Submission statuses. Submissions are received, reviewed, then selected or rejected.
+const (
+	SubmissionStatusReceived = "received"
+	SubmissionStatusInReview = "in_review"
+	SubmissionStatusSelected = "selected"
+	SubmissionStatusRejected = "rejected"
+)
+
+// Submission represents a film submitted by a filmmaker for a festival edition.
+type Submission struct {
+	ID            int                  `json:"id"`                       // Submission ID
+	UserID        int                  `json:"user_id,omitempty"`        // Submitting filmmaker, hidden from reviewers in blind mode
+	SubmitterName string               `json:"submitter_name,omitempty"` // Display name of the filmmaker, hidden from reviewers in blind mode
+	EditionID     int                  `json:"edition_id"`               // Edition the film is submitted to
+	SectionID     *int                 `json:"section_id,omitempty"`     // Programme section applied for (nullable)
+	Title         string               `json:"title"`                    // Film title
+	Description   string               `json:"description"`              // Film description
+	Duration      string               `json:"duration"`                 // Film duration
+	Artist        string               `json:"artist,omitempty"`         // Main artist (actor/director), hidden from reviewers in blind mode
+	GenreID       *int                 `json:"genre_id,omitempty"`       // Genre ID (nullable)
+	WatchURL      string               `json:"watch_url"`                // URL of the uploaded film
+	Status        string               `json:"status"`                   // received, in_review, selected or rejected
+	MovieID       *int                 `json:"movie_id,omitempty"`       // Catalogue movie created from the submission
+	CreatedAt     time.Time            `json:"created_at"`               // Timestamp of the submission
+	UpdatedAt     time.Time            `json:"updated_at"`               // Timestamp of the last change
+	Notes         []SubmissionNote     `json:"notes,omitempty"`          // Programmer notes, included on detail requests
+	MyReview      *SubmissionReview    `json:"my_review,omitempty"`      // The reviewer's own assignment, included in review queues
+	Reviews       []SubmissionReview   `json:"reviews,omitempty"`        // Reviewer assignments, included on detail requests of review leads
+	Conflicts     []SubmissionConflict `json:"conflicts,omitempty"`      // Declared conflicts of interest, included on detail requests of review leads
+}
+
+// SubmissionNote is a programmer's note on a submission.
+type SubmissionNote struct {
+	ID         int       `json:"id"`          // Note ID
+	AuthorName string    `json:"author_name"` // Display name of the programmer
+	Body       string    `json:"body"`        // Note text
+	Shared     bool      `json:"shared"`      // Whether the filmmaker can read the note
+	CreatedAt  time.Time `json:"created_at"`  // Timestamp of the note
+}
+
+// RequestSubmissionStatus is the payload to move a submission to another status.
+type RequestSubmissionStatus struct {
+	Status string `json:"status" binding:"required"` // in_review, selected or rejected
+}
+
+// RequestSubmissionNote is the payload of a programmer note.
+type RequestSubmissionNote struct {
+	Body   string `json:"body" binding:"required"` // Note text
+	Shared bool   `json:"shared"`                  // Share the note with the filmmaker
+}
+
+// SubmissionReview is a reviewer's assignment to a submission with their score and comment.
+type SubmissionReview struct {
+	SubmissionID int        `json:"submission_id"`         // Reviewed submission
+	ReviewerID   int        `json:"reviewer_id"`           // Assigned reviewer
+	ReviewerName string     `json:"reviewer_name"`         // Display name of the reviewer
+	Score        *int       `json:"score"`                 // Score from 1 to 10, null until reviewed
+	Comment      string     `json:"comment"`               // Reviewer's comment
+	AssignedAt   time.Time  `json:"assigned_at"`           // Timestamp of the assignment
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"` // Timestamp of the last review
+}
+
+// SubmissionConflict is a conflict of interest declared by a reviewer on a submission.
+type SubmissionConflict struct {
+	ReviewerID   int       `json:"reviewer_id"`   // Declaring reviewer
+	ReviewerName string    `json:"reviewer_name"` // Display name of the reviewer
+	Reason       string    `json:"reason"`        // Why the reviewer cannot review the film
+	DeclaredAt   time.Time `json:"declared_at"`   // Timestamp of the declaration
+}
+
+// SubmissionSectionProgress summarizes the review of one programme section's submissions.
+type SubmissionSectionProgress struct {
+	SectionID      *int     `json:"section_id"`      // Programme section, null for submissions without one
+	SectionName    string   `json:"section_name"`    // Name of the section
+	Submissions    int      `json:"submissions"`     // Submissions in the section
+	Received       int      `json:"received"`        // Submissions not yet in review
+	InReview       int      `json:"in_review"`       // Submissions in review
+	Selected       int      `json:"selected"`        // Selected submissions
+	Rejected       int      `json:"rejected"`        // Rejected submissions
+	Unassigned     int      `json:"unassigned"`      // Submissions without any reviewer
+	Assignments    int      `json:"assignments"`     // Reviewer assignments
+	ReviewsDone    int      `json:"reviews_done"`    // Assignments with a score
+	ReviewsPending int      `json:"reviews_pending"` // Assignments still waiting for a score
+	Conflicts      int      `json:"conflicts"`       // Declared conflicts of interest
+	AverageScore   *float64 `json:"average_score"`   // Average review score, null without reviews
+}
+
+// SubmissionDashboard summarizes the review progress of an edition per programme section.
+type SubmissionDashboard struct {
+	EditionID   int                         `json:"edition_id"`   // Summarized edition
+	BlindReview bool                        `json:"blind_review"` // Whether filmmaker identities are hidden from reviewers
+	Sections    []SubmissionSectionProgress `json:"sections"`     // Progress per section
+}
+
+// RequestSubmissionReview is the payload of a reviewer's score and comment.
+type RequestSubmissionReview struct {
+	Score   int    `json:"score" binding:"required"` // Score from 1 to 10
+	Comment string `json:"comment"`                  // Optional comment
+}
+
+// RequestSubmissionConflict is the payload of a conflict-of-interest declaration.
+type RequestSubmissionConflict struct {
+	Reason string `json:"reason" binding:"required"` // Why the reviewer cannot review the film
+}
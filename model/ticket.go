@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// Ticket statuses. Held tickets become expired when they are not confirmed in time.
+const (
+	TicketStatusHeld      = "held"
+	TicketStatusConfirmed = "confirmed"
+	TicketStatusCancelled = "cancelled"
+	TicketStatusExpired   = "expired"
+)
+
+// Ticket represents a reservation of seats for a screening.
+type Ticket struct {
+	ID            int        `json:"id"`                        // Ticket ID
+	ScreeningID   int        `json:"screening_id"`              // Screening the seats are for
+	UserID        int        `json:"-"`                         // Owner of the ticket
+	Seats         int        `json:"seats"`                     // Number of seats
+	Status        string     `json:"status"`                    // held, confirmed, cancelled or expired
+	Code          string     `json:"-"`                         // Random code embedded in the QR payload
+	HoldExpiresAt *time.Time `json:"hold_expires_at,omitempty"` // Deadline to confirm a held ticket
+	CreatedAt     time.Time  `json:"created_at"`                // Timestamp of the reservation
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`    // Timestamp of the confirmation
+	CancelledAt   *time.Time `json:"cancelled_at,omitempty"`    // Timestamp of the cancellation
+	CheckedInAt   *time.Time `json:"checked_in_at,omitempty"`   // Timestamp the ticket was scanned at the door
+	MovieTitle    string     `json:"movie_title"`               // Title of the movie shown
+	VenueName     string     `json:"venue_name"`                // Name of the venue
+	StartsAt      time.Time  `json:"starts_at"`                 // Start time of the screening
+	QRPayload     string     `json:"qr_payload,omitempty"`      // Signed code to encode in the QR code, for confirmed tickets
+}
+
+// RequestReserveTickets is the payload to hold seats for a screening.
+type RequestReserveTickets struct {
+	Seats int `json:"seats" binding:"required"` // Number of seats to hold
+}
+
+// RequestCheckIn is the payload of a ticket scan at the door.
+type RequestCheckIn struct {
+	Code        string `json:"code" binding:"required"` // QR payload of the ticket
+	ScreeningID int    `json:"screening_id"`            // Screening being admitted; when set, tickets for other screenings are refused
+}
+
+// CheckIn is the result of a successful ticket scan.
+type CheckIn struct {
+	TicketID    int       `json:"ticket_id"`     // Ticket ID
+	ScreeningID int       `json:"screening_id"`  // Screening the ticket is for
+	Seats       int       `json:"seats"`         // Number of people to admit
+	MovieTitle  string    `json:"movie_title"`   // Title of the movie shown
+	StartsAt    time.Time `json:"starts_at"`     // Start time of the screening
+	CheckedInAt time.Time `json:"checked_in_at"` // Timestamp of the scan
+}
+
+// Attendance summarizes seat sales and check-ins of a screening.
+type Attendance struct {
+	ScreeningID int `json:"screening_id"` // Screening ID
+	Capacity    int `json:"capacity"`     // Seats at the venue
+	SeatsSold   int `json:"seats_sold"`   // Seats of confirmed tickets
+	CheckedIn   int `json:"checked_in"`   // Seats of tickets scanned at the door
+}
@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// RefreshToken represents a stored refresh token. The raw token is never persisted, only its hash.
+type RefreshToken struct {
+	ID           int        // Refresh token ID
+	UserID       int        // Owner of the token
+	TokenHash    string     // SHA-256 hash of the raw token
+	FamilyID     string     // Shared by every token rotated from the same login
+	TwoFactor    bool       // Whether the login passed the two-factor step
+	ExpiresAt    time.Time  // Expiry of the token
+	RevokedAt    *time.Time // Set once the token is rotated or revoked
+	ReplacedByID *int       // Token issued when this one was rotated
+}
+
+// RequestRefreshToken is the payload carrying a refresh token for refresh and logout.
+type RequestRefreshToken struct {
+	RefreshToken string `json:"refresh_token"` // Raw refresh token
+}
+
+// Purposes of single-use user tokens.
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeTwoFactorLogin    = "two_factor_login"
+)
+
+// RequestVerifyEmail is the payload confirming an email address.
+type RequestVerifyEmail struct {
+	Token string `json:"token"` // Token received by email
+}
+
+// RequestForgotPassword is the payload requesting a password reset email.
+type RequestForgotPassword struct {
+	Email string `json:"email"` // Account email
+}
+
+// RequestResetPassword is the payload setting a new password with a reset token.
+type RequestResetPassword struct {
+	Token    string `json:"token"`    // Token received by email
+	Password string `json:"password"` // New password
+}
+
+// RequestTwoFactorCode is the payload carrying a TOTP code, e.g. to enable two-factor authentication.
+type RequestTwoFactorCode struct {
+	Code string `json:"code"` // Six-digit code from the authenticator app
+}
+
+// RequestDisableTwoFactor is the payload turning two-factor authentication off.
+type RequestDisableTwoFactor struct {
+	Password string `json:"password"` // Current password
+	Code     string `json:"code"`     // TOTP or recovery code
+}
+
+// RequestTwoFactorLogin is the second login step of accounts with two-factor authentication.
+type RequestTwoFactorLogin struct {
+	MFAToken string `json:"mfa_token"` // Token returned by the password step
+	Code     string `json:"code"`      // TOTP or recovery code
+}
+
+// TwoFactorSetup is returned when enrolment starts.
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`           // Base32 secret for manual entry
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI to render as a QR code
+}
@@ -1,10 +1,96 @@
 package model
 
 // Users represents a user with their email, password, and other details.
+// It is the database record and is never serialized directly; API responses use UserProfile.
 type Users struct {
-	Id       int    `json:"id"`       // User ID
-	Email    string `json:"email"`    // User's Email
-	Password string `json:"password"` // User's Password
-	Gender   string `json:"gender"`   // User's Gender
-	Role     string `json:"role"`     // User's Role
+	Id       int    `json:"id"`     // User ID
+	Email    string `json:"email"`  // User's Email
+	Password string `json:"-"`      // User's bcrypt password hash
+	Gender   string `json:"gender"` // User's Gender
+	Role     string `json:"role"`   // User's Role
+
+	DisplayName string `json:"display_name,omitempty"` // Name shown instead of the email
+	AvatarURL   string `json:"avatar_url,omitempty"`   // Link to the user's avatar image
+	CreatedAt   string `json:"created_at,omitempty"`   // Registration timestamp
+
+	RegistrationIP  string  `json:"-"`                           // Client IP address used to register
+	DisabledAt      *string `json:"disabled_at,omitempty"`       // Timestamp the account was disabled by an admin
+	EmailVerifiedAt *string `json:"email_verified_at,omitempty"` // Timestamp the email address was confirmed
+	TOTPSecret      string  `json:"-"`                           // TOTP secret, pending until TOTPEnabledAt is set
+	TOTPEnabledAt   *string `json:"-"`                           // Timestamp two-factor authentication was enabled
+}
+
+// UserSummary is the admin view of a user account.
+type UserSummary struct {
+	Id         int     `json:"id"`                    // User ID
+	Email      string  `json:"email"`                 // User's Email
+	Gender     string  `json:"gender"`                // User's Gender
+	Role       string  `json:"role"`                  // User's Role
+	Flagged    bool    `json:"flagged"`               // Whether the account is flagged for abuse
+	CreatedAt  string  `json:"created_at"`            // Registration timestamp
+	DisabledAt *string `json:"disabled_at,omitempty"` // Timestamp the account was disabled
+}
+
+// RequestUpdateRole is the payload of an admin role change.
+type RequestUpdateRole struct {
+	Role string `json:"role"` // New role
+}
+
+// RequestRegister is the payload of a self-service registration.
+type RequestRegister struct {
+	Email    string `json:"email" form:"email"`       // User's Email
+	Password string `json:"password" form:"password"` // Plaintext password, hashed before storage
+	Gender   string `json:"gender" form:"gender"`     // User's Gender
+}
+
+// RequestLogin is the payload of a password login.
+type RequestLogin struct {
+	Email    string `json:"email" form:"email"`       // User's Email
+	Password string `json:"password" form:"password"` // Plaintext password
+}
+
+// UserProfile is the public view of a user account returned by the API.
+type UserProfile struct {
+	Id              int     `json:"id"`                          // User ID
+	Email           string  `json:"email"`                       // User's Email
+	Gender          string  `json:"gender"`                      // User's Gender
+	Role            string  `json:"role"`                        // User's Role
+	DisplayName     string  `json:"display_name"`                // Name shown instead of the email
+	AvatarURL       string  `json:"avatar_url"`                  // Link to the user's avatar image
+	CreatedAt       string  `json:"created_at"`                  // Registration timestamp
+	EmailVerifiedAt *string `json:"email_verified_at,omitempty"` // Timestamp the email address was confirmed
+	TwoFactor       bool    `json:"two_factor_enabled"`          // Whether two-factor authentication is on
+}
+
+// RequestUpdateProfile is the payload of a profile update; omitted fields are left unchanged.
+type RequestUpdateProfile struct {
+	Gender      *string `json:"gender"`       // New gender
+	DisplayName *string `json:"display_name"` // New display name; empty clears it
+	AvatarURL   *string `json:"avatar_url"`   // New avatar URL; empty clears it
+}
+
+// RequestChangePassword is the payload of a password change by the account owner.
+type RequestChangePassword struct {
+	CurrentPassword string `json:"current_password"` // Password the user logs in with today
+	NewPassword     string `json:"new_password"`     // Replacement password
+}
+
+// RequestDeleteAccount is the payload confirming an account deletion.
+type RequestDeleteAccount struct {
+	Password string `json:"password"` // Current password, required to delete the account
+}
+
+// Profile converts a user record into its API representation.
+func (u *Users) Profile() *UserProfile {
+	return &UserProfile{
+		Id:              u.Id,
+		Email:           u.Email,
+		Gender:          u.Gender,
+		Role:            u.Role,
+		DisplayName:     u.DisplayName,
+		AvatarURL:       u.AvatarURL,
+		CreatedAt:       u.CreatedAt,
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		TwoFactor:       u.TOTPEnabledAt != nil,
+	}
 }
@@ -0,0 +1,179 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval limits how often an unknown kid triggers a new JWKS download.
+const jwksRefreshInterval = time.Minute
+
+// IDTokenClaims are the ID token claims used to link or create an account.
+type IDTokenClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of an ID token.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*IDTokenClaims, error) {
+	metadata, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.signingKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("invalid id token: nonce mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("invalid id token: missing subject")
+	}
+
+	return claims, nil
+}
+
+// signingKey returns the provider key with the given kid, downloading the JWKS again when the kid is unknown.
+func (p *Provider) signingKey(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	stale := time.Since(p.keysAt) > jwksRefreshInterval
+	p.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+
+	if err := p.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	// Providers with a single key may omit the kid
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown kid %q", kid)
+}
+
+// jsonWebKey is a provider key as published in its JWKS.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refreshKeys downloads the provider JWKS and replaces the cached keys.
+func (p *Provider) refreshKeys(ctx context.Context) error {
+	metadata, err := p.getDiscovery(ctx)
+	if err != nil {
+		return err
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, metadata.JWKSURI, &document); err != nil {
+		return fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+
+	keys := make(map[string]interface{})
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			// Skip key types we cannot use instead of failing the whole set
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.keysAt = time.Now()
+	p.mu.Unlock()
+
+	return nil
+}
+
+// parseJWK converts an RSA or P-256 JWK into a public key.
+func parseJWK(jwk jsonWebKey) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url-encoded big-endian integer.
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,19 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"movies/utils"
+)
+
+// NewCodeVerifier returns a random PKCE code verifier (RFC 7636).
+func NewCodeVerifier() (string, error) {
+	// 32 random bytes hex-encoded give 64 characters, within the allowed 43-128
+	return utils.GenerateToken(32)
+}
+
+// CodeChallengeS256 derives the S256 code challenge sent with the authorization request.
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,197 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Provider is an OpenID Connect identity provider configured from the environment.
+type Provider struct {
+	Name         string // Key stored with linked identities, e.g. "google"
+	Issuer       string // Issuer URL; discovery is read from <issuer>/.well-known/openid-configuration
+	ClientID     string // Client registered with the provider
+	ClientSecret string // Client secret; empty for public clients relying on PKCE alone
+	RedirectURL  string // Callback URL registered with the provider
+	Scopes       []string
+
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]interface{} // Provider signing keys by kid
+	keysAt    time.Time
+}
+
+// discovery holds the fields of the provider metadata document used by the login flow.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewProviderFromEnv builds the provider configured by the OIDC_* variables.
+// It returns nil when OIDC_ISSUER is unset, which disables OIDC login.
+func NewProviderFromEnv() (*Provider, error) {
+	issuer := strings.TrimRight(os.Getenv("OIDC_ISSUER"), "/")
+	if issuer == "" {
+		return nil, nil
+	}
+
+	provider := &Provider{
+		Name:         os.Getenv("OIDC_PROVIDER_NAME"),
+		Issuer:       issuer,
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(os.Getenv("OIDC_SCOPES")),
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+	if provider.Name == "" {
+		provider.Name = "oidc"
+	}
+	if len(provider.Scopes) == 0 {
+		provider.Scopes = []string{"openid", "email", "profile"}
+	}
+	if provider.ClientID == "" || provider.RedirectURL == "" {
+		return nil, fmt.Errorf("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required when OIDC_ISSUER is set")
+	}
+
+	return provider, nil
+}
+
+// getDiscovery fetches the provider metadata once and caches it.
+func (p *Provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var metadata discovery
+	if err := p.getJSON(ctx, p.Issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+		return nil, fmt.Errorf("failed to fetch provider metadata: %w", err)
+	}
+
+	// The metadata must describe the configured issuer, otherwise tokens would be checked against the wrong one
+	if strings.TrimRight(metadata.Issuer, "/") != p.Issuer {
+		return nil, fmt.Errorf("provider metadata issuer %q does not match %q", metadata.Issuer, p.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("provider metadata is incomplete")
+	}
+
+	p.discovery = &metadata
+	return p.discovery, nil
+}
+
+// AuthCodeURL returns the provider URL that starts an authorization code login with PKCE.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.ClientID},
+		"redirect_uri":          {p.RedirectURL},
+		"scope":                 {strings.Join(p.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// tokenResponse is the token endpoint answer; only the ID token is used.
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// Exchange trades an authorization code and its PKCE verifier for the user's ID token.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	metadata, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"client_id":     {p.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if p.ClientSecret != "" {
+		request.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to call token endpoint: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d: %s", response.StatusCode, body)
+	}
+
+	var tokens tokenResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return "", fmt.Errorf("token response has no id_token")
+	}
+
+	return tokens.IDToken, nil
+}
+
+// getJSON fetches a JSON document from the provider.
+func (p *Provider) getJSON(ctx context.Context, target string, value interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", target, response.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(response.Body, 1<<20)).Decode(value)
+}
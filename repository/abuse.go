@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"movies/model"
+)
+
+type AbuseRepo struct {
+	DB *sql.DB
+}
+
+func NewAbuseRepo(DB *sql.DB) *AbuseRepo {
+	return &AbuseRepo{DB: DB}
+}
+
+// flaggableTables maps abuse event types to the table holding the event and its flagged column.
+var flaggableTables = map[string]string{
+	"view":         "movie_views",
+	"vote":         "user_votes",
+	"registration": "users",
+}
+
+// CountRecentViewsByUser counts playback sessions started by a user within the last number of seconds.
+func (r *AbuseRepo) CountRecentViewsByUser(userID, seconds int) (int, error) {
+	query := `
+		SELECT COUNT(1)
+		FROM movie_views
+		WHERE user_id = ? AND viewed_at >= NOW() - INTERVAL ? SECOND
+	`
+
+	var count int
+	err := r.DB.QueryRow(query, userID, seconds).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recent views by user: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountRecentViewsByIP counts playback sessions started from an IP address within the last number of seconds.
+func (r *AbuseRepo) CountRecentViewsByIP(ip string, seconds int) (int, error) {
+	query := `
+		SELECT COUNT(1)
+		FROM movie_views
+		WHERE ip_address = ? AND viewed_at >= NOW() - INTERVAL ? SECOND
+	`
+
+	var count int
+	err := r.DB.QueryRow(query, ip, seconds).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recent views by ip: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountRecentRegistrationsByIP counts accounts registered from an IP address within the last number of minutes.
+func (r *AbuseRepo) CountRecentRegistrationsByIP(ip string, minutes int) (int, error) {
+	query := `
+		SELECT COUNT(1)
+		FROM users
+		WHERE registration_ip = ? AND created_at >= NOW() - INTERVAL ? MINUTE
+	`
+
+	var count int
+	err := r.DB.QueryRow(query, ip, minutes).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recent registrations: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetWatchedSeconds sums the seconds a user has watched of a movie across all sessions.
+func (r *AbuseRepo) GetWatchedSeconds(userID, movieID int) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(duration), 0)
+		FROM movie_views
+		WHERE user_id = ? AND movie_id = ?
+	`
+
+	var seconds int
+	err := r.DB.QueryRow(query, userID, movieID).Scan(&seconds)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum watched seconds: %w", err)
+	}
+
+	return seconds, nil
+}
+
+// IsUserFlagged checks whether a user account is currently flagged.
+func (r *AbuseRepo) IsUserFlagged(userID int) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM users
+			WHERE id = ? AND flagged = 1
+		)
+	`
+
+	var flagged bool
+	err := r.DB.QueryRow(query, userID).Scan(&flagged)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user flag: %w", err)
+	}
+
+	return flagged, nil
+}
+
+// GetVoteID retrieves the ID of a user's vote row for a movie.
+func (r *AbuseRepo) GetVoteID(userID, movieID int) (int, error) {
+	query := `
+		SELECT id
+		FROM user_votes
+		WHERE user_id = ? AND movie_id = ?
+	`
+
+	var id int
+	err := r.DB.QueryRow(query, userID, movieID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get vote id: %w", err)
+	}
+
+	return id, nil
+}
+
+// SetEventFlagged marks or clears the flagged column of the row behind an abuse event.
+func (r *AbuseRepo) SetEventFlagged(eventType string, eventID int, flagged bool) error {
+	table, ok := flaggableTables[eventType]
+	if !ok {
+		return fmt.Errorf("unknown event type: %s", eventType)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET flagged = ? WHERE id = ?", table)
+	_, err := r.DB.Exec(query, flagged, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update flagged %s: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// CreateFlag inserts a new abuse flag.
+func (r *AbuseRepo) CreateFlag(flag *model.AbuseFlag) error {
+	query := `
+		INSERT INTO abuse_flags (event_type, event_id, user_id, ip_address, score, reason)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.DB.Exec(query, flag.EventType, flag.EventID, flag.UserID, flag.IPAddress, flag.Score, flag.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to create abuse flag: %w", err)
+	}
+
+	return nil
+}
+
+// ListFlags retrieves abuse flags, optionally filtered by status, newest first.
+func (r *AbuseRepo) ListFlags(status string) ([]model.AbuseFlag, error) {
+	query := `
+		SELECT id, event_type, event_id, user_id, COALESCE(ip_address, ''), score, reason, status, created_at, reviewed_by, reviewed_at
+		FROM abuse_flags
+	`
+	var params []interface{}
+	if status != "" {
+		query += " WHERE status = ?"
+		params = append(params, status)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+
+	rows, err := r.DB.Query(query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var flags []model.AbuseFlag
+	for rows.Next() {
+		var flag model.AbuseFlag
+		if err := rows.Scan(&flag.ID, &flag.EventType, &flag.EventID, &flag.UserID, &flag.IPAddress, &flag.Score,
+			&flag.Reason, &flag.Status, &flag.CreatedAt, &flag.ReviewedBy, &flag.ReviewedAt); err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, rows.Err()
+}
+
+// GetFlag retrieves a single abuse flag by ID.
+func (r *AbuseRepo) GetFlag(flagID int) (*model.AbuseFlag, error) {
+	query := `
+		SELECT id, event_type, event_id, user_id, COALESCE(ip_address, ''), score, reason, status, created_at, reviewed_by, reviewed_at
+		FROM abuse_flags
+		WHERE id = ?
+	`
+
+	var flag model.AbuseFlag
+	err := r.DB.QueryRow(query, flagID).Scan(&flag.ID, &flag.EventType, &flag.EventID, &flag.UserID, &flag.IPAddress,
+		&flag.Score, &flag.Reason, &flag.Status, &flag.CreatedAt, &flag.ReviewedBy, &flag.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flag, nil
+}
+
+// ReviewFlag records an admin decision on an abuse flag.
+func (r *AbuseRepo) ReviewFlag(flagID int, status string, reviewerID int) error {
+	query := `
+		UPDATE abuse_flags
+		SET status = ?, reviewed_by = ?, reviewed_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.DB.Exec(query, status, reviewerID, flagID)
+	if err != nil {
+		return fmt.Errorf("failed to review abuse flag: %w", err)
+	}
+
+	return nil
+}
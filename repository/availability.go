@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"movies/model"
+	"strings"
+)
+
+// availableMovieCondition keeps the movies (aliased movies) that are available now in the country
+// bound to its placeholder. Rules with a NULL bound leave that side of the window open.
+const availableMovieCondition = `NOT EXISTS (
+	SELECT 1
+	FROM movie_availability ma
+	WHERE ma.movie_id = movies.id
+		AND (ma.available_from > NOW() OR ma.available_until <= NOW() OR (ma.countries <> '' AND FIND_IN_SET(?, ma.countries) = 0))
+)`
+
+type AvailabilityRepo struct {
+	DB *sql.DB
+}
+
+func NewAvailabilityRepo(DB *sql.DB) *AvailabilityRepo {
+	return &AvailabilityRepo{DB: DB}
+}
+
+// GetAvailability retrieves the availability rules of a movie. It returns sql.ErrNoRows when the movie
+// has none.
+func (r *AvailabilityRepo) GetAvailability(movieID int) (*model.MovieAvailability, error) {
+	query := "SELECT movie_id, available_from, available_until, countries, max_viewers FROM movie_availability WHERE movie_id = ?"
+
+	var availability model.MovieAvailability
+	var countries string
+	err := r.DB.QueryRow(query, movieID).Scan(&availability.MovieID, &availability.AvailableFrom, &availability.AvailableUntil,
+		&countries, &availability.MaxViewers)
+	if err != nil {
+		return nil, err
+	}
+
+	availability.Countries = []string{}
+	if countries != "" {
+		availability.Countries = strings.Split(countries, ",")
+	}
+
+	return &availability, nil
+}
+
+// SaveAvailability creates or replaces the availability rules of a movie.
+func (r *AvailabilityRepo) SaveAvailability(availability *model.MovieAvailability) error {
+	query := `
+		INSERT INTO movie_availability (movie_id, available_from, available_until, countries, max_viewers)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE available_from = VALUES(available_from), available_until = VALUES(available_until),
+			countries = VALUES(countries), max_viewers = VALUES(max_viewers)
+	`
+	_, err := r.DB.Exec(query, availability.MovieID, availability.AvailableFrom, availability.AvailableUntil,
+		strings.Join(availability.Countries, ","), availability.MaxViewers)
+	return err
+}
+
+// DeleteAvailability removes the availability rules of a movie. It reports whether the movie had any.
+func (r *AvailabilityRepo) DeleteAvailability(movieID int) (bool, error) {
+	result, err := r.DB.Exec("DELETE FROM movie_availability WHERE movie_id = ?", movieID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"movies/model"
+	"time"
+)
+
+type AwardsRepo struct {
+	DB *sql.DB
+}
+
+func NewAwardsRepo(DB *sql.DB) *AwardsRepo {
+	return &AwardsRepo{DB: DB}
+}
+
+// categorySelect selects award categories. published is computed by the database so it
+// follows the same clock as published_at.
+const categorySelect = `
+	SELECT id, edition_id, name, COALESCE(description, ''), kind, published_at,
+		published_at IS NOT NULL AND published_at <= NOW() AS published
+	FROM award_categories
+`
+
+// scanCategory scans a row selected with categorySelect.
+func scanCategory(row interface{ Scan(...interface{}) error }) (*model.AwardCategory, error) {
+	var category model.AwardCategory
+	err := row.Scan(&category.ID, &category.EditionID, &category.Name, &category.Description, &category.Kind,
+		&category.PublishedAt, &category.Published)
+	if err != nil {
+		return nil, err
+	}
+	category.Nominations = []model.AwardNomination{}
+
+	return &category, nil
+}
+
+// ListCategories retrieves the award categories of an edition with their nominations.
+// A zero editionID lists the categories of every edition.
+func (r *AwardsRepo) ListCategories(editionID int) ([]model.AwardCategory, error) {
+	rows, err := r.DB.Query(categorySelect+" WHERE ? = 0 OR edition_id = ? ORDER BY edition_id DESC, id ASC", editionID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []model.AwardCategory{}
+	positions := make(map[int]int)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		positions[category.ID] = len(categories)
+		categories = append(categories, *category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT an.category_id, an.movie_id, m.title, an.citation, an.is_winner
+		FROM award_nominations an
+		INNER JOIN award_categories ac ON ac.id = an.category_id
+		INNER JOIN movies m ON m.id = an.movie_id
+		WHERE ? = 0 OR ac.edition_id = ?
+		ORDER BY an.is_winner DESC, m.title ASC
+	`
+
+	nominationRows, err := r.DB.Query(query, editionID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	defer nominationRows.Close()
+
+	for nominationRows.Next() {
+		var categoryID int
+		var nomination model.AwardNomination
+		if err := nominationRows.Scan(&categoryID, &nomination.MovieID, &nomination.Title, &nomination.Citation, &nomination.IsWinner); err != nil {
+			return nil, err
+		}
+		if i, ok := positions[categoryID]; ok {
+			categories[i].Nominations = append(categories[i].Nominations, nomination)
+		}
+	}
+
+	return categories, nominationRows.Err()
+}
+
+// GetCategory retrieves an award category with its nominations.
+// It returns sql.ErrNoRows when the category does not exist.
+func (r *AwardsRepo) GetCategory(categoryID int) (*model.AwardCategory, error) {
+	category, err := scanCategory(r.DB.QueryRow(categorySelect+" WHERE id = ?", categoryID))
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT an.movie_id, m.title, an.citation, an.is_winner
+		FROM award_nominations an
+		INNER JOIN movies m ON m.id = an.movie_id
+		WHERE an.category_id = ?
+		ORDER BY an.is_winner DESC, m.title ASC
+	`
+
+	rows, err := r.DB.Query(query, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var nomination model.AwardNomination
+		if err := rows.Scan(&nomination.MovieID, &nomination.Title, &nomination.Citation, &nomination.IsWinner); err != nil {
+			return nil, err
+		}
+		category.Nominations = append(category.Nominations, nomination)
+	}
+
+	return category, rows.Err()
+}
+
+// CreateCategory inserts a new award category and sets its generated ID.
+func (r *AwardsRepo) CreateCategory(category *model.AwardCategory) error {
+	query := "INSERT INTO award_categories (edition_id, name, description, kind) VALUES (?, ?, ?, ?)"
+	result, err := r.DB.Exec(query, category.EditionID, category.Name, category.Description, category.Kind)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	category.ID = int(id)
+
+	return nil
+}
+
+// UpdateCategory replaces the name, description and kind of an award category.
+func (r *AwardsRepo) UpdateCategory(category *model.AwardCategory) error {
+	query := "UPDATE award_categories SET name = ?, description = ?, kind = ? WHERE id = ?"
+	_, err := r.DB.Exec(query, category.Name, category.Description, category.Kind, category.ID)
+	return err
+}
+
+// DeleteCategory deletes an award category with its nominations.
+func (r *AwardsRepo) DeleteCategory(categoryID int) error {
+	_, err := r.DB.Exec("DELETE FROM award_categories WHERE id = ?", categoryID)
+	return err
+}
+
+// CategoryNameTaken reports whether another award of the edition already uses the name.
+func (r *AwardsRepo) CategoryNameTaken(editionID int, name string, excludeID int) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM award_categories WHERE edition_id = ? AND name = ? AND id <> ?)"
+
+	var exists bool
+	if err := r.DB.QueryRow(query, editionID, name, excludeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check award name: %w", err)
+	}
+
+	return exists, nil
+}
+
+// SaveNomination nominates a movie for an award, or updates the citation of an existing nomination.
+func (r *AwardsRepo) SaveNomination(categoryID, movieID int, citation string) error {
+	query := `
+		INSERT INTO award_nominations (category_id, movie_id, citation)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE citation = VALUES(citation)
+	`
+	_, err := r.DB.Exec(query, categoryID, movieID, citation)
+	return err
+}
+
+// DeleteNomination withdraws a nomination. It reports whether the movie was nominated.
+func (r *AwardsRepo) DeleteNomination(categoryID, movieID int) (bool, error) {
+	result, err := r.DB.Exec("DELETE FROM award_nominations WHERE category_id = ? AND movie_id = ?", categoryID, movieID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// SetWinners marks the given nominees of an award as its winners and every other nominee as not winning.
+func (r *AwardsRepo) SetWinners(categoryID int, movieIDs []int) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE award_nominations SET is_winner = 0 WHERE category_id = ?", categoryID); err != nil {
+		return err
+	}
+	for _, movieID := range movieIDs {
+		if _, err := tx.Exec("UPDATE award_nominations SET is_winner = 1 WHERE category_id = ? AND movie_id = ?", categoryID, movieID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceWinners replaces every nomination of an award with the given winners.
+func (r *AwardsRepo) ReplaceWinners(categoryID int, winners []model.AwardNomination) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM award_nominations WHERE category_id = ?", categoryID); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO award_nominations (category_id, movie_id, citation, is_winner) VALUES (?, ?, ?, 1)"
+	for _, winner := range winners {
+		if _, err := tx.Exec(query, categoryID, winner.MovieID, winner.Citation); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PublishCategory schedules the announcement of an award's winners, immediately when publishedAt is nil.
+func (r *AwardsRepo) PublishCategory(categoryID int, publishedAt *time.Time) error {
+	_, err := r.DB.Exec("UPDATE award_categories SET published_at = COALESCE(?, NOW()) WHERE id = ?", publishedAt, categoryID)
+	return err
+}
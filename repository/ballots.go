@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"database/sql"
+	"movies/model"
+)
+
+type BallotsRepo struct {
+	DB *sql.DB
+}
+
+func NewBallotsRepo(DB *sql.DB) *BallotsRepo {
+	return &BallotsRepo{DB: DB}
+}
+
+// GetAttendedTicket retrieves the first checked-in ticket of a user for a screening of a movie.
+// It returns sql.ErrNoRows when the user attended no screening of the movie.
+func (r *BallotsRepo) GetAttendedTicket(userID, movieID int) (int, error) {
+	query := `
+		SELECT t.id
+		FROM tickets t
+		INNER JOIN screenings s ON s.id = t.screening_id
+		WHERE t.user_id = ? AND s.movie_id = ? AND t.checked_in_at IS NOT NULL
+		ORDER BY t.checked_in_at ASC
+		LIMIT 1
+	`
+
+	var ticketID int
+	err := r.DB.QueryRow(query, userID, movieID).Scan(&ticketID)
+	return ticketID, err
+}
+
+// GetLongestOnlineSession returns the most seconds a user has watched of a movie in a single online
+// session, counting each session at most up to runtimeSeconds and leaving out sessions flagged as abusive.
+func (r *BallotsRepo) GetLongestOnlineSession(userID, movieID, runtimeSeconds int) (int, error) {
+	query := `
+		SELECT COALESCE(MAX(LEAST(duration, ?)), 0)
+		FROM movie_views
+		WHERE user_id = ? AND movie_id = ? AND source = 'online' AND flagged = 0
+	`
+
+	var seconds int
+	err := r.DB.QueryRow(query, runtimeSeconds, userID, movieID).Scan(&seconds)
+	return seconds, err
+}
+
+// Create stores a ballot and sets its generated ID. It reports false when the user already cast a
+// ballot for the movie.
+func (r *BallotsRepo) Create(userID int, ballot *model.Ballot, ticketID *int) (bool, error) {
+	query := `
+		INSERT INTO audience_ballots (user_id, movie_id, score, source, ticket_id)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = id
+	`
+	result, err := r.DB.Exec(query, userID, ballot.MovieID, ballot.Score, ballot.Source, ticketID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, err
+	}
+	ballot.ID = int(id)
+
+	return true, nil
+}
+
+// ListUserBallots retrieves the ballots of a user, newest first.
+func (r *BallotsRepo) ListUserBallots(userID int) ([]model.Ballot, error) {
+	query := `
+		SELECT b.id, b.movie_id, m.title, b.score, b.source, b.created_at
+		FROM audience_ballots b
+		INNER JOIN movies m ON m.id = b.movie_id
+		WHERE b.user_id = ?
+		ORDER BY b.created_at DESC, b.id DESC
+	`
+
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ballots := []model.Ballot{}
+	for rows.Next() {
+		var ballot model.Ballot
+		if err := rows.Scan(&ballot.ID, &ballot.MovieID, &ballot.Title, &ballot.Score, &ballot.Source, &ballot.CreatedAt); err != nil {
+			return nil, err
+		}
+		ballots = append(ballots, ballot)
+	}
+
+	return ballots, rows.Err()
+}
+
+// GetTally sums the ballots for the movies of an edition, best average score first and the most
+// ballots first among equal averages. Ballots of flagged accounts are left out.
+func (r *BallotsRepo) GetTally(editionID int) ([]model.BallotTallyMovie, error) {
+	query := `
+		SELECT m.id, m.title, COUNT(*), SUM(b.source = 'screening'), SUM(b.source = 'online'), AVG(b.score)
+		FROM audience_ballots b
+		INNER JOIN movies m ON m.id = b.movie_id
+		INNER JOIN users u ON u.id = b.user_id
+		WHERE m.edition_id = ? AND u.flagged = 0
+		GROUP BY m.id, m.title
+		ORDER BY AVG(b.score) DESC, COUNT(*) DESC, m.id ASC
+	`
+
+	rows, err := r.DB.Query(query, editionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := []model.BallotTallyMovie{}
+	for rows.Next() {
+		var movie model.BallotTallyMovie
+		err := rows.Scan(&movie.MovieID, &movie.Title, &movie.Ballots, &movie.ScreeningBallots, &movie.OnlineBallots, &movie.AverageScore)
+		if err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, rows.Err()
+}
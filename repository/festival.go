@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"movies/model"
+)
+
+type FestivalRepo struct {
+	DB *sql.DB
+}
+
+func NewFestivalRepo(DB *sql.DB) *FestivalRepo {
+	return &FestivalRepo{DB: DB}
+}
+
+// editionColumns selects an edition with its dates formatted as YYYY-MM-DD.
+const editionColumns = `id, year, theme, DATE_FORMAT(starts_on, '%Y-%m-%d'), DATE_FORMAT(ends_on, '%Y-%m-%d'), blind_review`
+
+// ListEditions retrieves all festival editions, newest first.
+func (r *FestivalRepo) ListEditions() ([]model.FestivalEdition, error) {
+	rows, err := r.DB.Query("SELECT " + editionColumns + " FROM festival_editions ORDER BY starts_on DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	editions := []model.FestivalEdition{}
+	for rows.Next() {
+		var edition model.FestivalEdition
+		if err := rows.Scan(&edition.ID, &edition.Year, &edition.Theme, &edition.StartsOn, &edition.EndsOn, &edition.BlindReview); err != nil {
+			return nil, err
+		}
+		editions = append(editions, edition)
+	}
+
+	return editions, rows.Err()
+}
+
+// GetEdition retrieves an edition by ID. It returns sql.ErrNoRows when the edition does not exist.
+func (r *FestivalRepo) GetEdition(editionID int) (*model.FestivalEdition, error) {
+	var edition model.FestivalEdition
+	err := r.DB.QueryRow("SELECT "+editionColumns+" FROM festival_editions WHERE id = ?", editionID).
+		Scan(&edition.ID, &edition.Year, &edition.Theme, &edition.StartsOn, &edition.EndsOn, &edition.BlindReview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &edition, nil
+}
+
+// GetCurrentEdition retrieves the edition that is running or most recently started,
+// falling back to the next upcoming one. It returns sql.ErrNoRows when there are no editions.
+func (r *FestivalRepo) GetCurrentEdition() (*model.FestivalEdition, error) {
+	query := "SELECT " + editionColumns + `
+		FROM festival_editions
+		ORDER BY starts_on <= CURDATE() DESC,
+			CASE WHEN starts_on <= CURDATE() THEN starts_on END DESC,
+			starts_on ASC
+		LIMIT 1
+	`
+
+	var edition model.FestivalEdition
+	err := r.DB.QueryRow(query).Scan(&edition.ID, &edition.Year, &edition.Theme, &edition.StartsOn, &edition.EndsOn, &edition.BlindReview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &edition, nil
+}
+
+// CreateEdition inserts a new edition and sets its generated ID.
+func (r *FestivalRepo) CreateEdition(edition *model.FestivalEdition) error {
+	query := "INSERT INTO festival_editions (year, theme, starts_on, ends_on, blind_review) VALUES (?, ?, ?, ?, ?)"
+	result, err := r.DB.Exec(query, edition.Year, edition.Theme, edition.StartsOn, edition.EndsOn, edition.BlindReview)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	edition.ID = int(id)
+
+	return nil
+}
+
+// UpdateEdition replaces the year, theme, dates and review mode of an edition.
+func (r *FestivalRepo) UpdateEdition(edition *model.FestivalEdition) error {
+	query := "UPDATE festival_editions SET year = ?, theme = ?, starts_on = ?, ends_on = ?, blind_review = ? WHERE id = ?"
+	_, err := r.DB.Exec(query, edition.Year, edition.Theme, edition.StartsOn, edition.EndsOn, edition.BlindReview, edition.ID)
+	return err
+}
+
+// DeleteEdition deletes an edition together with its programme sections.
+func (r *FestivalRepo) DeleteEdition(editionID int) error {
+	_, err := r.DB.Exec("DELETE FROM festival_editions WHERE id = ?", editionID)
+	return err
+}
+
+// YearTaken reports whether another edition already uses the year.
+func (r *FestivalRepo) YearTaken(year, excludeID int) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM festival_editions WHERE year = ? AND id <> ?)", year, excludeID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check edition year: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CountEditionMovies counts the movies assigned to an edition.
+func (r *FestivalRepo) CountEditionMovies(editionID int) (int, error) {
+	var count int
+	err := r.DB.QueryRow("SELECT COUNT(1) FROM movies WHERE edition_id = ?", editionID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count edition movies: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListSections retrieves the programme sections of an edition ordered by name.
+func (r *FestivalRepo) ListSections(editionID int) ([]model.ProgrammeSection, error) {
+	query := `
+		SELECT id, edition_id, name, COALESCE(description, '')
+		FROM programme_sections
+		WHERE edition_id = ?
+		ORDER BY name ASC
+	`
+
+	rows, err := r.DB.Query(query, editionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := []model.ProgrammeSection{}
+	for rows.Next() {
+		var section model.ProgrammeSection
+		if err := rows.Scan(&section.ID, &section.EditionID, &section.Name, &section.Description); err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+
+	return sections, rows.Err()
+}
+
+// GetSection retrieves a programme section by ID. It returns sql.ErrNoRows when the section does not exist.
+func (r *FestivalRepo) GetSection(sectionID int) (*model.ProgrammeSection, error) {
+	query := "SELECT id, edition_id, name, COALESCE(description, '') FROM programme_sections WHERE id = ?"
+
+	var section model.ProgrammeSection
+	err := r.DB.QueryRow(query, sectionID).Scan(&section.ID, &section.EditionID, &section.Name, &section.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	return &section, nil
+}
+
+// CreateSection inserts a new programme section and sets its generated ID.
+func (r *FestivalRepo) CreateSection(section *model.ProgrammeSection) error {
+	query := "INSERT INTO programme_sections (edition_id, name, description) VALUES (?, ?, ?)"
+	result, err := r.DB.Exec(query, section.EditionID, section.Name, section.Description)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	section.ID = int(id)
+
+	return nil
+}
+
+// UpdateSection replaces the name and description of a programme section.
+func (r *FestivalRepo) UpdateSection(section *model.ProgrammeSection) error {
+	_, err := r.DB.Exec("UPDATE programme_sections SET name = ?, description = ? WHERE id = ?", section.Name, section.Description, section.ID)
+	return err
+}
+
+// DeleteSection deletes a programme section. Its movies stay in the edition without a section.
+func (r *FestivalRepo) DeleteSection(sectionID int) error {
+	_, err := r.DB.Exec("DELETE FROM programme_sections WHERE id = ?", sectionID)
+	return err
+}
+
+// SectionNameTaken reports whether another section of the edition already uses the name.
+func (r *FestivalRepo) SectionNameTaken(editionID int, name string, excludeID int) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM programme_sections WHERE edition_id = ? AND name = ? AND id <> ?)"
+
+	var exists bool
+	if err := r.DB.QueryRow(query, editionID, name, excludeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check section name: %w", err)
+	}
+
+	return exists, nil
+}
+
+// SectionHasJury reports whether a programme section has a jury panel.
+func (r *FestivalRepo) SectionHasJury(sectionID int) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM jury_panels WHERE section_id = ?)", sectionID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check section jury: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,441 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"movies/model"
+	"time"
+)
+
+type JuryRepo struct {
+	DB *sql.DB
+}
+
+func NewJuryRepo(DB *sql.DB) *JuryRepo {
+	return &JuryRepo{DB: DB}
+}
+
+// panelSelect selects jury panels joined with their section.
+const panelSelect = `
+	SELECT p.id, p.section_id, s.name, s.edition_id, p.name, p.locked_at
+	FROM jury_panels p
+	INNER JOIN programme_sections s ON s.id = p.section_id
+`
+
+// scanPanel scans a row selected with panelSelect, followed by any extra columns.
+func scanPanel(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*model.JuryPanel, error) {
+	var panel model.JuryPanel
+	dest := append([]interface{}{&panel.ID, &panel.SectionID, &panel.SectionName, &panel.EditionID, &panel.Name, &panel.LockedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return &panel, nil
+}
+
+// ListPanels retrieves every jury panel, newest edition first.
+func (r *JuryRepo) ListPanels() ([]model.JuryPanel, error) {
+	rows, err := r.DB.Query(panelSelect + " ORDER BY s.edition_id DESC, p.name ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	panels := []model.JuryPanel{}
+	for rows.Next() {
+		panel, err := scanPanel(rows)
+		if err != nil {
+			return nil, err
+		}
+		panels = append(panels, *panel)
+	}
+
+	return panels, rows.Err()
+}
+
+// ListUserPanels retrieves the panels a user sits on, marking the ones they preside.
+func (r *JuryRepo) ListUserPanels(userID int) ([]model.JuryPanel, error) {
+	query := `
+		SELECT p.id, p.section_id, s.name, s.edition_id, p.name, p.locked_at, jm.is_president
+		FROM jury_panels p
+		INNER JOIN programme_sections s ON s.id = p.section_id
+		INNER JOIN jury_members jm ON jm.panel_id = p.id
+		WHERE jm.user_id = ?
+		ORDER BY s.edition_id DESC, p.name ASC
+	`
+
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	panels := []model.JuryPanel{}
+	for rows.Next() {
+		var isPresident bool
+		panel, err := scanPanel(rows, &isPresident)
+		if err != nil {
+			return nil, err
+		}
+		panel.IsPresident = &isPresident
+		panels = append(panels, *panel)
+	}
+
+	return panels, rows.Err()
+}
+
+// GetPanel retrieves a jury panel by ID. It returns sql.ErrNoRows when the panel does not exist.
+func (r *JuryRepo) GetPanel(panelID int) (*model.JuryPanel, error) {
+	return scanPanel(r.DB.QueryRow(panelSelect+" WHERE p.id = ?", panelID))
+}
+
+// CreatePanel inserts a new jury panel and sets its generated ID.
+func (r *JuryRepo) CreatePanel(panel *model.JuryPanel) error {
+	result, err := r.DB.Exec("INSERT INTO jury_panels (section_id, name) VALUES (?, ?)", panel.SectionID, panel.Name)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	panel.ID = int(id)
+
+	return nil
+}
+
+// DeletePanel deletes a jury panel with its members, criteria and scores.
+func (r *JuryRepo) DeletePanel(panelID int) error {
+	_, err := r.DB.Exec("DELETE FROM jury_panels WHERE id = ?", panelID)
+	return err
+}
+
+// ListMembers retrieves the jurors of a panel, president first.
+func (r *JuryRepo) ListMembers(panelID int) ([]model.JuryMember, error) {
+	query := `
+		SELECT jm.user_id, COALESCE(u.display_name, ''), jm.is_president
+		FROM jury_members jm
+		INNER JOIN users u ON u.id = jm.user_id
+		WHERE jm.panel_id = ?
+		ORDER BY jm.is_president DESC, jm.created_at ASC
+	`
+
+	rows, err := r.DB.Query(query, panelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []model.JuryMember{}
+	for rows.Next() {
+		var member model.JuryMember
+		if err := rows.Scan(&member.UserID, &member.DisplayName, &member.IsPresident); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// GetMember retrieves a juror of a panel. It returns sql.ErrNoRows when the user is not on the panel.
+func (r *JuryRepo) GetMember(panelID, userID int) (*model.JuryMember, error) {
+	query := `
+		SELECT jm.user_id, COALESCE(u.display_name, ''), jm.is_president
+		FROM jury_members jm
+		INNER JOIN users u ON u.id = jm.user_id
+		WHERE jm.panel_id = ? AND jm.user_id = ?
+	`
+
+	var member model.JuryMember
+	if err := r.DB.QueryRow(query, panelID, userID).Scan(&member.UserID, &member.DisplayName, &member.IsPresident); err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// SaveMember adds a juror to a panel or updates their presidency. Appointing a president
+// demotes the previous one, so a panel has at most one.
+func (r *JuryRepo) SaveMember(panelID, userID int, isPresident bool) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if isPresident {
+		if _, err := tx.Exec("UPDATE jury_members SET is_president = 0 WHERE panel_id = ? AND user_id <> ?", panelID, userID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO jury_members (panel_id, user_id, is_president)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE is_president = VALUES(is_president)
+	`
+	if _, err := tx.Exec(query, panelID, userID, isPresident); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteMember removes a juror from a panel together with their scores.
+func (r *JuryRepo) DeleteMember(panelID, userID int) error {
+	_, err := r.DB.Exec("DELETE FROM jury_members WHERE panel_id = ? AND user_id = ?", panelID, userID)
+	return err
+}
+
+// ListCriteria retrieves the scoring criteria of a panel in creation order.
+func (r *JuryRepo) ListCriteria(panelID int) ([]model.JuryCriterion, error) {
+	rows, err := r.DB.Query("SELECT id, panel_id, name, weight FROM jury_criteria WHERE panel_id = ? ORDER BY id ASC", panelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	criteria := []model.JuryCriterion{}
+	for rows.Next() {
+		var criterion model.JuryCriterion
+		if err := rows.Scan(&criterion.ID, &criterion.PanelID, &criterion.Name, &criterion.Weight); err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	return criteria, rows.Err()
+}
+
+// CreateCriterion inserts a new scoring criterion and sets its generated ID.
+func (r *JuryRepo) CreateCriterion(criterion *model.JuryCriterion) error {
+	result, err := r.DB.Exec("INSERT INTO jury_criteria (panel_id, name, weight) VALUES (?, ?, ?)", criterion.PanelID, criterion.Name, criterion.Weight)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	criterion.ID = int(id)
+
+	return nil
+}
+
+// DeleteCriterion deletes a criterion of a panel together with its scores.
+// It reports whether the criterion belonged to the panel.
+func (r *JuryRepo) DeleteCriterion(panelID, criterionID int) (bool, error) {
+	result, err := r.DB.Exec("DELETE FROM jury_criteria WHERE id = ? AND panel_id = ?", criterionID, panelID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// CriterionNameTaken reports whether a panel already has a criterion with the name.
+func (r *JuryRepo) CriterionNameTaken(panelID int, name string) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM jury_criteria WHERE panel_id = ? AND name = ?)", panelID, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check criterion name: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListSectionMovies retrieves the movies of a programme section ordered by title.
+func (r *JuryRepo) ListSectionMovies(sectionID int) ([]model.JuryMovie, error) {
+	rows, err := r.DB.Query("SELECT id, title FROM movies WHERE section_id = ? ORDER BY title ASC", sectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := []model.JuryMovie{}
+	for rows.Next() {
+		var movie model.JuryMovie
+		if err := rows.Scan(&movie.ID, &movie.Title); err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, rows.Err()
+}
+
+// ListUserScores retrieves the scores a juror gave on a panel.
+func (r *JuryRepo) ListUserScores(panelID, userID int) ([]model.JuryScore, error) {
+	query := `
+		SELECT movie_id, criterion_id, score, updated_at
+		FROM jury_scores
+		WHERE panel_id = ? AND user_id = ?
+		ORDER BY movie_id ASC, criterion_id ASC
+	`
+
+	rows, err := r.DB.Query(query, panelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := []model.JuryScore{}
+	for rows.Next() {
+		var score model.JuryScore
+		if err := rows.Scan(&score.MovieID, &score.CriterionID, &score.Score, &score.UpdatedAt); err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, rows.Err()
+}
+
+// SaveScores stores a juror's scores for a movie, replacing earlier ones for the same criteria.
+// The panel row is locked while writing, so scores cannot slip in after LockPanel.
+// It returns false when the panel is already locked.
+func (r *JuryRepo) SaveScores(panelID, userID, movieID int, scores []model.JuryScoreInput) (bool, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var lockedAt *time.Time
+	if err := tx.QueryRow("SELECT locked_at FROM jury_panels WHERE id = ? FOR UPDATE", panelID).Scan(&lockedAt); err != nil {
+		return false, err
+	}
+	if lockedAt != nil {
+		return false, nil
+	}
+
+	query := `
+		INSERT INTO jury_scores (panel_id, user_id, movie_id, criterion_id, score)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE score = VALUES(score)
+	`
+	for _, score := range scores {
+		if _, err := tx.Exec(query, panelID, userID, movieID, score.CriterionID, score.Score); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+// GetRankings ranks the movies of a section by the weighted average of a panel's scores,
+// with the average per criterion. Movies nobody scored come last with a score of 0.
+func (r *JuryRepo) GetRankings(panelID, sectionID int) ([]model.JuryRanking, error) {
+	query := `
+		SELECT m.id, m.title, SUM(js.score * jc.weight) / SUM(jc.weight) AS weighted, COUNT(DISTINCT js.user_id)
+		FROM movies m
+		LEFT JOIN jury_scores js ON js.movie_id = m.id AND js.panel_id = ?
+		LEFT JOIN jury_criteria jc ON jc.id = js.criterion_id
+		WHERE m.section_id = ?
+		GROUP BY m.id, m.title
+		ORDER BY weighted DESC, m.title ASC
+	`
+
+	rows, err := r.DB.Query(query, panelID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rankings := []model.JuryRanking{}
+	positions := make(map[int]int)
+	for rows.Next() {
+		var ranking model.JuryRanking
+		var score sql.NullFloat64
+		if err := rows.Scan(&ranking.MovieID, &ranking.Title, &score, &ranking.Jurors); err != nil {
+			return nil, err
+		}
+		ranking.Score = score.Float64
+		ranking.Criteria = []model.JuryCriterionAverage{}
+		positions[ranking.MovieID] = len(rankings)
+		rankings = append(rankings, ranking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	averageQuery := `
+		SELECT js.movie_id, jc.id, jc.name, AVG(js.score)
+		FROM jury_scores js
+		INNER JOIN jury_criteria jc ON jc.id = js.criterion_id
+		INNER JOIN movies m ON m.id = js.movie_id
+		WHERE js.panel_id = ? AND m.section_id = ?
+		GROUP BY js.movie_id, jc.id, jc.name
+		ORDER BY jc.id ASC
+	`
+
+	averageRows, err := r.DB.Query(averageQuery, panelID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer averageRows.Close()
+
+	for averageRows.Next() {
+		var movieID int
+		var average model.JuryCriterionAverage
+		if err := averageRows.Scan(&movieID, &average.CriterionID, &average.Name, &average.Average); err != nil {
+			return nil, err
+		}
+		if i, ok := positions[movieID]; ok {
+			rankings[i].Criteria = append(rankings[i].Criteria, average)
+		}
+	}
+
+	return rankings, averageRows.Err()
+}
+
+// LockPanel finalizes a panel's scores once every juror scored every movie of the section on
+// every criterion. It returns the number of missing scores, which is 0 when the panel was locked,
+// and false as second value when the panel was already locked.
+func (r *JuryRepo) LockPanel(panelID, sectionID, userID int) (int, bool, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	// Block score writes while counting
+	var lockedAt *time.Time
+	if err := tx.QueryRow("SELECT locked_at FROM jury_panels WHERE id = ? FOR UPDATE", panelID).Scan(&lockedAt); err != nil {
+		return 0, false, err
+	}
+	if lockedAt != nil {
+		return 0, false, nil
+	}
+
+	query := `
+		SELECT
+			(SELECT COUNT(1) FROM jury_members WHERE panel_id = ?) *
+			(SELECT COUNT(1) FROM jury_criteria WHERE panel_id = ?) *
+			(SELECT COUNT(1) FROM movies WHERE section_id = ?),
+			(SELECT COUNT(1) FROM jury_scores js INNER JOIN movies m ON m.id = js.movie_id
+				WHERE js.panel_id = ? AND m.section_id = ?)
+	`
+
+	var expected, scored int
+	if err := tx.QueryRow(query, panelID, panelID, sectionID, panelID, sectionID).Scan(&expected, &scored); err != nil {
+		return 0, false, err
+	}
+	if scored < expected {
+		return expected - scored, true, nil
+	}
+
+	if _, err := tx.Exec("UPDATE jury_panels SET locked_at = NOW(), locked_by = ? WHERE id = ?", userID, panelID); err != nil {
+		return 0, false, err
+	}
+
+	return 0, true, tx.Commit()
+}
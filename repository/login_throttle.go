@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"movies/model"
+	"time"
+)
+
+type LoginThrottleRepo struct {
+	DB *sql.DB
+}
+
+func NewLoginThrottleRepo(DB *sql.DB) *LoginThrottleRepo {
+	return &LoginThrottleRepo{DB: DB}
+}
+
+// GetLockRemaining returns how long a subject stays locked out, or zero if it is not locked.
+// Times are computed by the database so they do not depend on the connection time zone.
+func (r *LoginThrottleRepo) GetLockRemaining(scope, subject string) (time.Duration, error) {
+	query := `
+		SELECT TIMESTAMPDIFF(SECOND, NOW(), locked_until)
+		FROM login_throttles
+		WHERE scope = ? AND subject = ? AND locked_until > NOW()
+	`
+
+	var seconds int
+	err := r.DB.QueryRow(query, scope, subject).Scan(&seconds)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// Round partial seconds up so a locked subject never reports zero
+	return time.Duration(seconds+1) * time.Second, nil
+}
+
+// RecordFailure counts a failed login for a subject and returns the new number of consecutive failures.
+// The count starts over once the subject has had no failure and no lockout for windowMinutes.
+func (r *LoginThrottleRepo) RecordFailure(scope, subject string, windowMinutes int) (int, error) {
+	query := `
+		INSERT INTO login_throttles (scope, subject, failed_count, last_failed_at)
+		VALUES (?, ?, 1, NOW())
+		ON DUPLICATE KEY UPDATE
+			failed_count = IF(GREATEST(last_failed_at, COALESCE(locked_until, last_failed_at)) < NOW() - INTERVAL ? MINUTE, 1, failed_count + 1),
+			last_failed_at = NOW()
+	`
+
+	if _, err := r.DB.Exec(query, scope, subject, windowMinutes); err != nil {
+		return 0, err
+	}
+
+	var failedCount int
+	err := r.DB.QueryRow("SELECT failed_count FROM login_throttles WHERE scope = ? AND subject = ?", scope, subject).Scan(&failedCount)
+	return failedCount, err
+}
+
+// Lock locks a subject out for the given duration.
+func (r *LoginThrottleRepo) Lock(scope, subject string, duration time.Duration) error {
+	query := "UPDATE login_throttles SET locked_until = NOW() + INTERVAL ? SECOND WHERE scope = ? AND subject = ?"
+	_, err := r.DB.Exec(query, int(duration.Seconds()), scope, subject)
+	return err
+}
+
+// Clear forgets the failed attempts of a subject, lifting any lockout.
+func (r *LoginThrottleRepo) Clear(scope, subject string) error {
+	_, err := r.DB.Exec("DELETE FROM login_throttles WHERE scope = ? AND subject = ?", scope, subject)
+	return err
+}
+
+// ListLocked retrieves the subjects of a scope that are currently locked out.
+// Account entries carry the user ID when the email belongs to a registered user.
+func (r *LoginThrottleRepo) ListLocked(scope string) ([]model.LoginThrottle, error) {
+	query := `
+		SELECT lt.scope, lt.subject, u.id, lt.failed_count, lt.last_failed_at, lt.locked_until
+		FROM login_throttles lt
+		LEFT JOIN users u ON lt.scope = 'account' AND u.email = lt.subject
+		WHERE lt.scope = ? AND lt.locked_until > NOW()
+		ORDER BY lt.locked_until DESC
+	`
+
+	rows, err := r.DB.Query(query, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	throttles := []model.LoginThrottle{}
+	for rows.Next() {
+		var throttle model.LoginThrottle
+		if err := rows.Scan(&throttle.Scope, &throttle.Subject, &throttle.UserID, &throttle.FailedCount,
+			&throttle.LastFailedAt, &throttle.LockedUntil); err != nil {
+			return nil, err
+		}
+		throttles = append(throttles, throttle)
+	}
+
+	return throttles, rows.Err()
+}
@@ -17,8 +17,8 @@ func NewMoviesRepo(DB *sql.DB) *MoviesRepo {
 
 // Create inserts a new movie into the database.
 func (pr *MoviesRepo) Create(Movie *model.Movies) (*model.Movies, error) {
-	sql_insert := "INSERT INTO movies (title, description, duration, artist, genre_id, watch_url) VALUES (?,?,?,?,?,?)"
-	_, err := pr.DB.Exec(sql_insert, Movie.Title, Movie.Description, Movie.Duration, Movie.Artist, Movie.GenreID, Movie.WatchURL)
+	sql_insert := "INSERT INTO movies (title, description, duration, artist, genre_id, watch_url, edition_id, section_id) VALUES (?,?,?,?,?,?,?,?)"
+	_, err := pr.DB.Exec(sql_insert, Movie.Title, Movie.Description, Movie.Duration, Movie.Artist, Movie.GenreID, Movie.WatchURL, Movie.EditionID, Movie.SectionID)
 
 	if err != nil {
 		log.Println("ERR insert Movies : ", err)
@@ -51,18 +51,31 @@ func (repo *MoviesRepo) Update(movieID int, updates map[string]interface{}) erro
 }
 
 // GetAllMoviesWithPagination retrieves a list of movies from the database with pagination.
-func (r *MoviesRepo) GetAllMoviesWithPagination(page, limit int) ([]model.Movies, error) {
+// A non-zero editionID limits the list to movies of that festival edition, and a non-nil availableIn
+// to the movies available online now in that country.
+func (r *MoviesRepo) GetAllMoviesWithPagination(page, limit, editionID int, availableIn *string) ([]model.Movies, error) {
 	// Calculate the offset based on the page and limit.
 	offset := (page - 1) * limit
+	var queryParams []interface{}
+	where := "WHERE 1=1"
+	if editionID != 0 {
+		where += " AND edition_id = ?"
+		queryParams = append(queryParams, editionID)
+	}
+	if availableIn != nil {
+		where += " AND " + availableMovieCondition
+		queryParams = append(queryParams, *availableIn)
+	}
 	query := fmt.Sprintf(`
-		SELECT id, title, description, duration, artist, genre_id, watch_url
+		SELECT id, title, description, duration, artist, genre_id, watch_url, edition_id, section_id
 		FROM movies
+		%s
 		ORDER BY id ASC
 		LIMIT %d OFFSET %d
-	`, limit, offset)
+	`, where, limit, offset)
 
 	// Execute the query and get the result rows.
-	rows, err := r.DB.Query(query)
+	rows, err := r.DB.Query(query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +85,7 @@ func (r *MoviesRepo) GetAllMoviesWithPagination(page, limit int) ([]model.Movies
 	var movies []model.Movies
 	for rows.Next() {
 		var movie model.Movies
-		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.Artist, &movie.GenreID, &movie.WatchURL); err != nil {
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.Artist, &movie.GenreID, &movie.WatchURL, &movie.EditionID, &movie.SectionID); err != nil {
 			return nil, err
 		}
 		movies = append(movies, movie)
@@ -82,10 +95,11 @@ func (r *MoviesRepo) GetAllMoviesWithPagination(page, limit int) ([]model.Movies
 	return movies, nil
 }
 
-// SearchMovies searches for movies by title, description, artist, or genre ID.
-func (r *MoviesRepo) SearchMovies(title string, description string, artist string, genreID int) ([]model.Movies, error) {
+// SearchMovies searches for movies by title, description, artist, genre ID, or festival edition ID.
+// A non-nil availableIn keeps only the movies available online now in that country.
+func (r *MoviesRepo) SearchMovies(title string, description string, artist string, genreID int, editionID int, availableIn *string) ([]model.Movies, error) {
 	// Start building the search query.
-	sqlQuery := "SELECT id, title, description, duration, artist, genre_id, watch_url FROM movies WHERE 1=1"
+	sqlQuery := "SELECT id, title, description, duration, artist, genre_id, watch_url, edition_id, section_id FROM movies WHERE 1=1"
 	var queryParams []interface{}
 
 	// Dynamically add search conditions for each parameter.
@@ -105,6 +119,14 @@ func (r *MoviesRepo) SearchMovies(title string, description string, artist strin
 		sqlQuery += " AND genre_id = ?"
 		queryParams = append(queryParams, genreID)
 	}
+	if editionID != 0 {
+		sqlQuery += " AND edition_id = ?"
+		queryParams = append(queryParams, editionID)
+	}
+	if availableIn != nil {
+		sqlQuery += " AND " + availableMovieCondition
+		queryParams = append(queryParams, *availableIn)
+	}
 
 	// Execute the query and get the result rows.
 	rows, err := r.DB.Query(sqlQuery, queryParams...)
@@ -117,7 +139,7 @@ func (r *MoviesRepo) SearchMovies(title string, description string, artist strin
 	var moviesList []model.Movies
 	for rows.Next() {
 		var movie model.Movies
-		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.Artist, &movie.GenreID, &movie.WatchURL); err != nil {
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.Artist, &movie.GenreID, &movie.WatchURL, &movie.EditionID, &movie.SectionID); err != nil {
 			return nil, err
 		}
 		moviesList = append(moviesList, movie)
@@ -127,6 +149,34 @@ func (r *MoviesRepo) SearchMovies(title string, description string, artist strin
 	return moviesList, nil
 }
 
+// GetMovie retrieves a movie by ID. It returns sql.ErrNoRows when the movie does not exist.
+func (r *MoviesRepo) GetMovie(movieID int) (*model.Movies, error) {
+	query := `
+		SELECT id, title, COALESCE(description, ''), COALESCE(duration, ''), COALESCE(artist, ''), genre_id, watch_url, edition_id, section_id
+		FROM movies
+		WHERE id = ?
+	`
+
+	var movie model.Movies
+	err := r.DB.QueryRow(query, movieID).Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.Artist, &movie.GenreID, &movie.WatchURL, &movie.EditionID, &movie.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &movie, nil
+}
+
+// GetMovieEdition retrieves the festival edition a movie is assigned to, or nil when it has none.
+func (r *MoviesRepo) GetMovieEdition(movieID int) (*int, error) {
+	var editionID *int
+	err := r.DB.QueryRow("SELECT edition_id FROM movies WHERE id = ?", movieID).Scan(&editionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return editionID, nil
+}
+
 // MovieExists checks if a movie with the given ID exists in the database.
 func (r *MoviesRepo) MovieExists(movieID int) (bool, error) {
 	query := `
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+)
+
+type OIDCRepo struct {
+	DB *sql.DB
+}
+
+func NewOIDCRepo(DB *sql.DB) *OIDCRepo {
+	return &OIDCRepo{DB: DB}
+}
+
+// CreateLoginState stores a pending login and purges expired ones.
+func (r *OIDCRepo) CreateLoginState(state, nonce, codeVerifier string, expiresAt time.Time) error {
+	if _, err := r.DB.Exec("DELETE FROM oidc_login_states WHERE expires_at < NOW()"); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO oidc_login_states (state, nonce, code_verifier, expires_at) VALUES (?, ?, ?, ?)"
+	_, err := r.DB.Exec(query, state, nonce, codeVerifier, expiresAt)
+	return err
+}
+
+// ConsumeLoginState deletes a pending login and returns its nonce and PKCE verifier.
+// It returns sql.ErrNoRows if the state is unknown, expired or already used.
+func (r *OIDCRepo) ConsumeLoginState(state string) (string, string, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback()
+
+	var nonce, codeVerifier string
+	query := "SELECT nonce, code_verifier FROM oidc_login_states WHERE state = ? AND expires_at > NOW() FOR UPDATE"
+	if err := tx.QueryRow(query, state).Scan(&nonce, &codeVerifier); err != nil {
+		return "", "", err
+	}
+
+	if _, err := tx.Exec("DELETE FROM oidc_login_states WHERE state = ?", state); err != nil {
+		return "", "", err
+	}
+
+	return nonce, codeVerifier, tx.Commit()
+}
+
+// GetIdentityUserID returns the user linked to a provider subject, or sql.ErrNoRows if none is.
+func (r *OIDCRepo) GetIdentityUserID(provider, subject string) (int, error) {
+	var userID int
+	err := r.DB.QueryRow("SELECT user_id FROM user_identities WHERE provider = ? AND subject = ?", provider, subject).Scan(&userID)
+	return userID, err
+}
+
+// CreateIdentity links a provider subject to a user.
+func (r *OIDCRepo) CreateIdentity(userID int, provider, subject, email string) error {
+	query := "INSERT INTO user_identities (user_id, provider, subject, email, last_login_at) VALUES (?, ?, ?, ?, NOW())"
+	_, err := r.DB.Exec(query, userID, provider, subject, email)
+	return err
+}
+
+// TouchIdentity records a login through a linked identity.
+func (r *OIDCRepo) TouchIdentity(provider, subject, email string) error {
+	query := "UPDATE user_identities SET last_login_at = NOW(), email = ? WHERE provider = ? AND subject = ?"
+	_, err := r.DB.Exec(query, email, provider, subject)
+	return err
+}
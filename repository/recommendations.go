@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"database/sql"
+	"movies/model"
+	"strings"
+)
+
+type RecommendationsRepo struct {
+	DB *sql.DB
+}
+
+func NewRecommendationsRepo(DB *sql.DB) *RecommendationsRepo {
+	return &RecommendationsRepo{DB: DB}
+}
+
+// GetCatalog retrieves every movie used as a recommendation candidate.
+func (r *RecommendationsRepo) GetCatalog() ([]model.Movies, error) {
+	query := `
+		SELECT id, title, description, duration, artist, genre_id, watch_url
+		FROM movies
+		ORDER BY id ASC
+	`
+
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movies []model.Movies
+	for rows.Next() {
+		var movie model.Movies
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.Artist, &movie.GenreID, &movie.WatchURL); err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, rows.Err()
+}
+
+// GetAvailableMovieIDs retrieves the IDs of the movies available online now in the given country.
+func (r *RecommendationsRepo) GetAvailableMovieIDs(country string) (map[int]bool, error) {
+	rows, err := r.DB.Query("SELECT id FROM movies WHERE "+availableMovieCondition, country)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	available := make(map[int]bool)
+	for rows.Next() {
+		var movieID int
+		if err := rows.Scan(&movieID); err != nil {
+			return nil, err
+		}
+		available[movieID] = true
+	}
+
+	return available, rows.Err()
+}
+
+// GetModelVersion returns when the stored collaborative model was trained, or "" when there is none.
+// "movies recommend train" runs in its own process, so servers compare it to spot a new model.
+func (r *RecommendationsRepo) GetModelVersion() (string, error) {
+	var trainedAt sql.NullString
+	err := r.DB.QueryRow("SELECT MAX(trained_at) FROM movie_similarities").Scan(&trainedAt)
+	return trainedAt.String, err
+}
+
+// GetUserVotes retrieves the user's votes as a map of movie ID to whether the vote is a like.
+func (r *RecommendationsRepo) GetUserVotes(userID int) (map[int]bool, error) {
+	query := `
+		SELECT movie_id, is_like
+		FROM user_votes
+		WHERE user_id = ?
+	`
+
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := make(map[int]bool)
+	for rows.Next() {
+		var movieID int
+		var isLike bool
+		if err := rows.Scan(&movieID, &isLike); err != nil {
+			return nil, err
+		}
+		votes[movieID] = isLike
+	}
+
+	return votes, rows.Err()
+}
+
+// GetUserViewedMovieIDs retrieves the IDs of the movies the user has viewed.
+func (r *RecommendationsRepo) GetUserViewedMovieIDs(userID int) ([]int, error) {
+	query := `
+		SELECT DISTINCT movie_id
+		FROM movie_views
+		WHERE user_id = ?
+	`
+
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movieIDs []int
+	for rows.Next() {
+		var movieID int
+		if err := rows.Scan(&movieID); err != nil {
+			return nil, err
+		}
+		movieIDs = append(movieIDs, movieID)
+	}
+
+	return movieIDs, rows.Err()
+}
+
+// GetInteractions retrieves the implicit ratings of every registered user, ignoring events flagged as abusive.
+// Watching a movie rates it 1; a like adds 2 and a dislike subtracts 2.
+func (r *RecommendationsRepo) GetInteractions() ([]model.UserInteraction, error) {
+	query := `
+		SELECT user_id, movie_id, SUM(rating) AS rating
+		FROM (
+			SELECT DISTINCT user_id, movie_id, 1 AS rating
+			FROM movie_views
+			WHERE user_id IS NOT NULL AND flagged = 0
+			UNION ALL
+			SELECT user_id, movie_id, CASE WHEN is_like = 1 THEN 2 WHEN is_unlike = 1 THEN -2 ELSE 0 END AS rating
+			FROM user_votes
+			WHERE user_id IS NOT NULL AND movie_id IS NOT NULL AND flagged = 0
+		) interactions
+		GROUP BY user_id, movie_id
+	`
+
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var interactions []model.UserInteraction
+	for rows.Next() {
+		var interaction model.UserInteraction
+		if err := rows.Scan(&interaction.UserID, &interaction.MovieID, &interaction.Rating); err != nil {
+			return nil, err
+		}
+		interactions = append(interactions, interaction)
+	}
+
+	return interactions, rows.Err()
+}
+
+// ReplaceSimilarities swaps the stored similarity model for a freshly trained one in a single transaction.
+func (r *RecommendationsRepo) ReplaceSimilarities(similarities []model.MovieSimilarity) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM movie_similarities"); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO movie_similarities (movie_id, similar_movie_id, score, trained_at) VALUES (?, ?, ?, NOW())")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, similarity := range similarities {
+		if _, err := stmt.Exec(similarity.MovieID, similarity.SimilarMovieID, similarity.Score); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetSimilarities retrieves the trained neighbours of the given movies.
+func (r *RecommendationsRepo) GetSimilarities(movieIDs []int) ([]model.MovieSimilarity, error) {
+	if len(movieIDs) == 0 {
+		return nil, nil
+	}
+
+	query := "SELECT movie_id, similar_movie_id, score FROM movie_similarities WHERE movie_id IN (?" + strings.Repeat(",?", len(movieIDs)-1) + ")"
+	params := make([]interface{}, len(movieIDs))
+	for i, movieID := range movieIDs {
+		params[i] = movieID
+	}
+
+	rows, err := r.DB.Query(query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var similarities []model.MovieSimilarity
+	for rows.Next() {
+		var similarity model.MovieSimilarity
+		if err := rows.Scan(&similarity.MovieID, &similarity.SimilarMovieID, &similarity.Score); err != nil {
+			return nil, err
+		}
+		similarities = append(similarities, similarity)
+	}
+
+	return similarities, rows.Err()
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"movies/model"
+)
+
+type RolesRepo struct {
+	DB *sql.DB
+}
+
+func NewRolesRepo(DB *sql.DB) *RolesRepo {
+	return &RolesRepo{DB: DB}
+}
+
+// RoleHasPermission checks whether a role grants a permission.
+func (r *RolesRepo) RoleHasPermission(role, permission string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM role_permissions
+			WHERE role = ? AND permission = ?
+		)
+	`
+
+	var granted bool
+	err := r.DB.QueryRow(query, role, permission).Scan(&granted)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission: %w", err)
+	}
+
+	return granted, nil
+}
+
+// RoleExists checks whether a role is defined.
+func (r *RolesRepo) RoleExists(role string) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM roles WHERE name = ?)", role).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check role existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListRoles retrieves every role with its permissions.
+func (r *RolesRepo) ListRoles() ([]model.Role, error) {
+	query := `
+		SELECT r.name, r.description, rp.permission
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		ORDER BY r.name ASC, rp.permission ASC
+	`
+
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []model.Role
+	for rows.Next() {
+		var name, description string
+		var permission sql.NullString
+		if err := rows.Scan(&name, &description, &permission); err != nil {
+			return nil, err
+		}
+
+		// Rows are ordered by role, so a new name starts a new role
+		if len(roles) == 0 || roles[len(roles)-1].Name != name {
+			roles = append(roles, model.Role{Name: name, Description: description, Permissions: []string{}})
+		}
+		if permission.Valid {
+			last := &roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, permission.String)
+		}
+	}
+
+	return roles, rows.Err()
+}
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"time"
+)
+
+type ScreeningsRepo struct {
+	DB *sql.DB
+}
+
+func NewScreeningsRepo(DB *sql.DB) *ScreeningsRepo {
+	return &ScreeningsRepo{DB: DB}
+}
+
+// ListVenues retrieves all venues ordered by name.
+func (r *ScreeningsRepo) ListVenues() ([]model.Venue, error) {
+	rows, err := r.DB.Query("SELECT id, name, capacity, address FROM venues ORDER BY name ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	venues := []model.Venue{}
+	for rows.Next() {
+		var venue model.Venue
+		if err := rows.Scan(&venue.ID, &venue.Name, &venue.Capacity, &venue.Address); err != nil {
+			return nil, err
+		}
+		venues = append(venues, venue)
+	}
+
+	return venues, rows.Err()
+}
+
+// GetVenue retrieves a venue by ID. It returns sql.ErrNoRows when the venue does not exist.
+func (r *ScreeningsRepo) GetVenue(venueID int) (*model.Venue, error) {
+	var venue model.Venue
+	err := r.DB.QueryRow("SELECT id, name, capacity, address FROM venues WHERE id = ?", venueID).
+		Scan(&venue.ID, &venue.Name, &venue.Capacity, &venue.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &venue, nil
+}
+
+// CreateVenue inserts a new venue and sets its generated ID.
+func (r *ScreeningsRepo) CreateVenue(venue *model.Venue) error {
+	result, err := r.DB.Exec("INSERT INTO venues (name, capacity, address) VALUES (?, ?, ?)", venue.Name, venue.Capacity, venue.Address)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	venue.ID = int(id)
+
+	return nil
+}
+
+// activeTicketCondition keeps the tickets (aliased t) whose seats are taken: confirmed tickets and
+// holds that have not run out.
+const activeTicketCondition = "(t.status = 'confirmed' OR (t.status = 'held' AND t.hold_expires_at > NOW()))"
+
+// UpdateVenue replaces the name, capacity and address of a venue. The capacity cannot drop below the
+// seats taken for an upcoming screening at the venue; those screenings are locked while counting, so
+// no reservation can slip in. It returns "capacity below seats sold" when it would.
+func (r *ScreeningsRepo) UpdateVenue(venue *model.Venue) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT id FROM screenings WHERE venue_id = ? AND ends_at > NOW() FOR UPDATE", venue.ID)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	soldQuery := `
+		SELECT COALESCE(MAX(taken), 0)
+		FROM (
+			SELECT SUM(t.seats) AS taken
+			FROM tickets t
+			INNER JOIN screenings s ON s.id = t.screening_id
+			WHERE s.venue_id = ? AND s.ends_at > NOW() AND ` + activeTicketCondition + `
+			GROUP BY t.screening_id
+		) sold
+	`
+	var sold int
+	if err := tx.QueryRow(soldQuery, venue.ID).Scan(&sold); err != nil {
+		return err
+	}
+	if venue.Capacity < sold {
+		return fmt.Errorf("capacity below seats sold")
+	}
+
+	if _, err := tx.Exec("UPDATE venues SET name = ?, capacity = ?, address = ? WHERE id = ?", venue.Name, venue.Capacity, venue.Address, venue.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteVenue deletes a venue.
+func (r *ScreeningsRepo) DeleteVenue(venueID int) error {
+	_, err := r.DB.Exec("DELETE FROM venues WHERE id = ?", venueID)
+	return err
+}
+
+// VenueNameTaken reports whether another venue already uses the name.
+func (r *ScreeningsRepo) VenueNameTaken(name string, excludeID int) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM venues WHERE name = ? AND id <> ?)", name, excludeID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check venue name: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CountVenueScreenings counts the screenings scheduled at a venue.
+func (r *ScreeningsRepo) CountVenueScreenings(venueID int) (int, error) {
+	var count int
+	err := r.DB.QueryRow("SELECT COUNT(1) FROM screenings WHERE venue_id = ?", venueID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count venue screenings: %w", err)
+	}
+
+	return count, nil
+}
+
+// screeningSelect selects screenings joined with their movie title and venue name.
+const screeningSelect = `
+	SELECT s.id, s.movie_id, m.title, s.venue_id, v.name, s.starts_at, s.ends_at, s.language, s.subtitles
+	FROM screenings s
+	INNER JOIN movies m ON m.id = s.movie_id
+	INNER JOIN venues v ON v.id = s.venue_id
+`
+
+// scanScreening scans a row selected with screeningSelect.
+func scanScreening(row interface{ Scan(...interface{}) error }) (*model.Screening, error) {
+	var screening model.Screening
+	err := row.Scan(&screening.ID, &screening.MovieID, &screening.MovieTitle, &screening.VenueID, &screening.VenueName,
+		&screening.StartsAt, &screening.EndsAt, &screening.Language, &screening.Subtitles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &screening, nil
+}
+
+// GetScreening retrieves a screening by ID. It returns sql.ErrNoRows when the screening does not exist.
+func (r *ScreeningsRepo) GetScreening(screeningID int) (*model.Screening, error) {
+	return scanScreening(r.DB.QueryRow(screeningSelect+" WHERE s.id = ?", screeningID))
+}
+
+// ListScreenings retrieves the screenings starting in [from, to), ordered by start time.
+// A non-zero venueID limits the list to one venue.
+func (r *ScreeningsRepo) ListScreenings(from, to time.Time, venueID int) ([]model.Screening, error) {
+	query := screeningSelect + `
+		WHERE s.starts_at >= ? AND s.starts_at < ? AND (? = 0 OR s.venue_id = ?)
+		ORDER BY s.starts_at ASC, v.name ASC
+	`
+
+	rows, err := r.DB.Query(query, from, to, venueID, venueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	screenings := []model.Screening{}
+	for rows.Next() {
+		screening, err := scanScreening(rows)
+		if err != nil {
+			return nil, err
+		}
+		screenings = append(screenings, *screening)
+	}
+
+	return screenings, rows.Err()
+}
+
+// SaveScreening inserts a screening, or updates it when its ID is set, unless it overlaps another
+// screening at the same venue. Screenings are kept buffer apart for cleanup between shows.
+// The venue row is locked for the duration of the check, so concurrent writes cannot both pass it.
+// It returns the first conflicting screening, or nil when the screening was saved. Moving a screening
+// with seats taken returns "screening has tickets".
+func (r *ScreeningsRepo) SaveScreening(screening *model.Screening, buffer time.Duration) (*model.Screening, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Serialize schedule changes per venue
+	var venueID int
+	if err := tx.QueryRow("SELECT id FROM venues WHERE id = ? FOR UPDATE", screening.VenueID).Scan(&venueID); err != nil {
+		return nil, err
+	}
+
+	// A screening with seats taken keeps its movie, venue and time; only language and subtitles may change.
+	// The screening row is locked like a reservation locks it, so no ticket can be sold meanwhile.
+	if screening.ID != 0 {
+		var moved bool
+		movedQuery := "SELECT movie_id <> ? OR venue_id <> ? OR starts_at <> ? FROM screenings WHERE id = ? FOR UPDATE"
+		err := tx.QueryRow(movedQuery, screening.MovieID, screening.VenueID, screening.StartsAt, screening.ID).Scan(&moved)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("screening not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if moved {
+			var tickets int
+			ticketsQuery := "SELECT COUNT(1) FROM tickets t WHERE t.screening_id = ? AND " + activeTicketCondition
+			if err := tx.QueryRow(ticketsQuery, screening.ID).Scan(&tickets); err != nil {
+				return nil, err
+			}
+			if tickets > 0 {
+				return nil, fmt.Errorf("screening has tickets")
+			}
+		}
+	}
+
+	// Two screenings overlap when each starts before the other ends plus the cleanup buffer
+	conflictQuery := screeningSelect + `
+		WHERE s.venue_id = ? AND s.id <> ? AND s.starts_at < ? AND s.ends_at > ?
+		ORDER BY s.starts_at ASC
+		LIMIT 1
+	`
+	conflict, err := scanScreening(tx.QueryRow(conflictQuery, screening.VenueID, screening.ID,
+		screening.EndsAt.Add(buffer), screening.StartsAt.Add(-buffer)))
+	if err == nil {
+		return conflict, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if screening.ID == 0 {
+		query := `
+			INSERT INTO screenings (movie_id, venue_id, starts_at, ends_at, language, subtitles)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`
+		result, err := tx.Exec(query, screening.MovieID, screening.VenueID, screening.StartsAt, screening.EndsAt, screening.Language, screening.Subtitles)
+		if err != nil {
+			return nil, err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		screening.ID = int(id)
+	} else {
+		query := `
+			UPDATE screenings
+			SET movie_id = ?, venue_id = ?, starts_at = ?, ends_at = ?, language = ?, subtitles = ?
+			WHERE id = ?
+		`
+		_, err := tx.Exec(query, screening.MovieID, screening.VenueID, screening.StartsAt, screening.EndsAt, screening.Language, screening.Subtitles, screening.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, tx.Commit()
+}
+
+// CountScreeningTickets counts all tickets ever issued for a screening.
+func (r *ScreeningsRepo) CountScreeningTickets(screeningID int) (int, error) {
+	var count int
+	err := r.DB.QueryRow("SELECT COUNT(1) FROM tickets WHERE screening_id = ?", screeningID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count screening tickets: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteScreening deletes a screening.
+func (r *ScreeningsRepo) DeleteScreening(screeningID int) error {
+	_, err := r.DB.Exec("DELETE FROM screenings WHERE id = ?", screeningID)
+	return err
+}
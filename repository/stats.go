@@ -15,16 +15,21 @@ func NewStatsRepo(DB *sql.DB) *StatsRepo {
 }
 
 // GetMostViewedMovies retrieves all movies with the highest number of views from the database.
-func (r *StatsRepo) GetMostViewedMovies() ([]model.MovieStatsView, error) {
+// Views flagged as abusive are excluded. A non-zero editionID limits the ranking to that festival edition.
+func (r *StatsRepo) GetMostViewedMovies(editionID int) ([]model.MovieStatsView, error) {
 	query := `
-		SELECT m.id, m.title, COUNT(mv.movie_id) AS views
+		SELECT m.id, m.title, COUNT(mv.id) AS plays,
+			COUNT(DISTINCT mv.user_id) + COUNT(DISTINCT mv.visitor_id) AS unique_viewers,
+			COUNT(mv.user_id) AS registered_views,
+			COUNT(mv.id) - COUNT(mv.user_id) AS anonymous_views
 		FROM movies m
-		LEFT JOIN movie_views mv ON m.id = mv.movie_id
+		LEFT JOIN movie_views mv ON m.id = mv.movie_id AND mv.flagged = 0
+		WHERE ? = 0 OR m.edition_id = ?
 		GROUP BY m.id, m.title
-		ORDER BY views DESC
+		ORDER BY plays DESC, unique_viewers DESC
 	`
 
-	rows, err := r.DB.Query(query)
+	rows, err := r.DB.Query(query, editionID, editionID)
 	if err != nil {
 		return nil, err
 	}
@@ -36,18 +41,18 @@ func (r *StatsRepo) GetMostViewedMovies() ([]model.MovieStatsView, error) {
 	for rows.Next() {
 		var movie model.MovieStatsView
 
-		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Views); err != nil {
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Plays, &movie.UniqueViewers, &movie.RegisteredViews, &movie.AnonymousViews); err != nil {
 			return nil, err
 		}
 
-		// Stop adding movies if their views are less than the maxViews
-		if len(movies) > 0 && movie.Views < maxViews {
+		// Stop adding movies if their plays are less than the maxViews
+		if len(movies) > 0 && movie.Plays < maxViews {
 			break
 		}
 
 		// Set maxViews for the first iteration
 		if len(movies) == 0 {
-			maxViews = movie.Views
+			maxViews = movie.Plays
 		}
 
 		movies = append(movies, movie)
@@ -56,18 +61,55 @@ func (r *StatsRepo) GetMostViewedMovies() ([]model.MovieStatsView, error) {
 	return movies, nil
 }
 
+// GetPopularMovies retrieves the movies with the most plays, up to limit.
+// Views flagged as abusive are excluded.
+func (r *StatsRepo) GetPopularMovies(limit int) ([]model.MovieStatsView, error) {
+	query := `
+		SELECT m.id, m.title, COUNT(mv.id) AS plays,
+			COUNT(DISTINCT mv.user_id) + COUNT(DISTINCT mv.visitor_id) AS unique_viewers,
+			COUNT(mv.user_id) AS registered_views,
+			COUNT(mv.id) - COUNT(mv.user_id) AS anonymous_views
+		FROM movies m
+		LEFT JOIN movie_views mv ON m.id = mv.movie_id AND mv.flagged = 0
+		GROUP BY m.id, m.title
+		ORDER BY plays DESC, unique_viewers DESC, m.id ASC
+		LIMIT ?
+	`
+
+	rows, err := r.DB.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movies []model.MovieStatsView
+	for rows.Next() {
+		var movie model.MovieStatsView
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Plays, &movie.UniqueViewers, &movie.RegisteredViews, &movie.AnonymousViews); err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, rows.Err()
+}
+
 // GetMostViewedGenres retrieves all genres with the highest number of views from the database.
-func (r *StatsRepo) GetMostViewedGenres() ([]model.GenreStats, error) {
+// Views flagged as abusive are excluded. A non-zero editionID only counts movies of that festival edition.
+func (r *StatsRepo) GetMostViewedGenres(editionID int) ([]model.GenreStats, error) {
 	query := `
-		SELECT g.id, g.name, COUNT(mv.movie_id) AS views
+		SELECT g.id, g.name, COUNT(mv.id) AS plays,
+			COUNT(DISTINCT mv.user_id) + COUNT(DISTINCT mv.visitor_id) AS unique_viewers,
+			COUNT(mv.user_id) AS registered_views,
+			COUNT(mv.id) - COUNT(mv.user_id) AS anonymous_views
 		FROM genres g
-		LEFT JOIN movies m ON g.id = m.genre_id
-		LEFT JOIN movie_views mv ON m.id = mv.movie_id
+		LEFT JOIN movies m ON g.id = m.genre_id AND (? = 0 OR m.edition_id = ?)
+		LEFT JOIN movie_views mv ON m.id = mv.movie_id AND mv.flagged = 0
 		GROUP BY g.id, g.name
-		ORDER BY views DESC
+		ORDER BY plays DESC, unique_viewers DESC
 	`
 
-	rows, err := r.DB.Query(query)
+	rows, err := r.DB.Query(query, editionID, editionID)
 	if err != nil {
 		return nil, err
 	}
@@ -79,18 +121,18 @@ func (r *StatsRepo) GetMostViewedGenres() ([]model.GenreStats, error) {
 	for rows.Next() {
 		var genre model.GenreStats
 
-		if err := rows.Scan(&genre.ID, &genre.Name, &genre.Views); err != nil {
+		if err := rows.Scan(&genre.ID, &genre.Name, &genre.Plays, &genre.UniqueViewers, &genre.RegisteredViews, &genre.AnonymousViews); err != nil {
 			return nil, err
 		}
 
-		// Stop adding genres if their views are less than the maxViews
-		if len(genres) > 0 && genre.Views < maxViews {
+		// Stop adding genres if their plays are less than the maxViews
+		if len(genres) > 0 && genre.Plays < maxViews {
 			break
 		}
 
 		// Set maxViews for the first iteration
 		if len(genres) == 0 {
-			maxViews = genre.Views
+			maxViews = genre.Plays
 		}
 
 		genres = append(genres, genre)
@@ -100,16 +142,18 @@ func (r *StatsRepo) GetMostViewedGenres() ([]model.GenreStats, error) {
 }
 
 // GetMostVotedMovies retrieves all movies with the most positive votes (is_like = 1).
-func (repo *StatsRepo) GetMostVotedMovies() ([]model.MovieStatsVote, error) {
+// Votes flagged as abusive are excluded. A non-zero editionID limits the ranking to that festival edition.
+func (repo *StatsRepo) GetMostVotedMovies(editionID int) ([]model.MovieStatsVote, error) {
 	query := `
 		SELECT m.id, m.title, COUNT(uv.id) AS vote_count
 		FROM movies m
-		LEFT JOIN user_votes uv ON m.id = uv.movie_id AND uv.is_like = 1
+		LEFT JOIN user_votes uv ON m.id = uv.movie_id AND uv.is_like = 1 AND uv.flagged = 0
+		WHERE ? = 0 OR m.edition_id = ?
 		GROUP BY m.id, m.title
 		ORDER BY vote_count DESC
 	`
 
-	rows, err := repo.DB.Query(query)
+	rows, err := repo.DB.Query(query, editionID, editionID)
 	if err != nil {
 		return nil, err
 	}
@@ -179,15 +223,16 @@ func (r *StatsRepo) CheckUnVote(userID, movieID int) (bool, error) {
 }
 
 // AddVote adds a "like" vote for a movie by the user.
-func (r *StatsRepo) AddVote(userID, movieID int) error {
+// A changed vote starts unflagged and is scored again.
+func (r *StatsRepo) AddVote(userID, movieID int, ip string) error {
 	// SQL query to insert or update the "like" vote for a movie
 	query := `
-		INSERT INTO user_votes (user_id, movie_id, created_at, is_like, is_unlike)
-		VALUES (?, ?, NOW(), 1, 0)
-		ON DUPLICATE KEY UPDATE is_like = 1, is_unlike = 0
+		INSERT INTO user_votes (user_id, movie_id, created_at, is_like, is_unlike, ip_address)
+		VALUES (?, ?, NOW(), 1, 0, ?)
+		ON DUPLICATE KEY UPDATE is_like = 1, is_unlike = 0, ip_address = VALUES(ip_address), flagged = 0
 	`
 	// Execute the query to add a "like" vote
-	_, err := r.DB.Exec(query, userID, movieID)
+	_, err := r.DB.Exec(query, userID, movieID, ip)
 	return err
 }
 
@@ -299,12 +344,15 @@ func (r *StatsRepo) MovieViewExists(userID int, movieID int) (bool, error) {
 	return count > 0, nil
 }
 
-// UpdateViewingDuration updates the viewing duration of a movie for a user
+// UpdateViewingDuration adds watched seconds to the user's latest open playback session of a movie,
+// with the same cap as HeartbeatViewSession
 func (r *StatsRepo) UpdateViewingDuration(userID, movieID, duration int) error {
 	query := `
 		UPDATE movie_views
-		SET duration = duration + ?
-		WHERE user_id = ? AND movie_id = ?
+		SET duration = ` + watchedSecondsIncrement + `, last_heartbeat_at = NOW()
+		WHERE user_id = ? AND movie_id = ? AND source = 'online' AND ended_at IS NULL
+		ORDER BY viewed_at DESC, id DESC
+		LIMIT 1
 	`
 
 	result, err := r.DB.Exec(query, duration, userID, movieID)
@@ -314,44 +362,182 @@ func (r *StatsRepo) UpdateViewingDuration(userID, movieID, duration int) error {
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("no record updated, check movie_id and user_id")
+		return fmt.Errorf("no open session updated, check movie_id and user_id")
 	}
 
 	return nil
 }
 
-// SaveMovieView saves a record of a user viewing a movie in the movie_views table.
+// SaveMovieView saves a new playback session of a movie in the movie_views table.
 func (r *StatsRepo) SaveMovieView(view *model.MovieView) error {
+	return saveMovieView(r.DB, view)
+}
+
+// SaveMovieViewWithinLimit saves a new online playback session unless the movie already has
+// maxViewers active sessions: open sessions with a heartbeat, or a start, in the last idleMinutes.
+// The movie's availability row is locked while counting, so concurrent sessions cannot both take the
+// last place. A movie whose rules were deleted meanwhile has no limit. It reports false when the movie is at capacity.
+func (r *StatsRepo) SaveMovieViewWithinLimit(view *model.MovieView, maxViewers, idleMinutes int) (bool, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var movieID int
+	err = tx.QueryRow("SELECT movie_id FROM movie_availability WHERE movie_id = ? FOR UPDATE", view.MovieID).Scan(&movieID)
+	if err == sql.ErrNoRows {
+		// The rules were deleted since the caller read them, so the movie has no viewer limit any more
+		if err := saveMovieView(tx, view); err != nil {
+			return false, err
+		}
+		return true, tx.Commit()
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock movie availability: %w", err)
+	}
+
 	query := `
-		INSERT INTO movie_views (movie_id, user_id, viewed_at, duration)
-		VALUES (?, ?, ?, ?)
+		SELECT COUNT(*)
+		FROM movie_views
+		WHERE movie_id = ? AND source = 'online' AND ended_at IS NULL
+			AND COALESCE(last_heartbeat_at, viewed_at) >= NOW() - INTERVAL ? MINUTE
 	`
 
+	var active int
+	if err := tx.QueryRow(query, view.MovieID, idleMinutes).Scan(&active); err != nil {
+		return false, fmt.Errorf("failed to count active viewers: %w", err)
+	}
+	if active >= maxViewers {
+		return false, nil
+	}
+
+	if err := saveMovieView(tx, view); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// saveMovieView inserts a playback session with db, which is the database or a transaction.
+func saveMovieView(db interface {
+	Exec(string, ...interface{}) (sql.Result, error)
+}, view *model.MovieView) error {
+	query := `
+		INSERT INTO movie_views (session_id, movie_id, user_id, visitor_id, viewed_at, duration, ip_address)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// Anonymous sessions store NULL for user_id, registered ones NULL for visitor_id.
+	var userID, visitorID interface{}
+	if view.UserID != 0 {
+		userID = view.UserID
+	} else {
+		visitorID = view.VisitorID
+	}
+
 	// Execute the insert query with the view data.
-	_, err := r.DB.Exec(query, view.MovieID, view.UserID, view.ViewedAt, 0)
+	result, err := db.Exec(query, view.SessionID, view.MovieID, userID, visitorID, view.ViewedAt, 0, view.IPAddress)
 	if err != nil {
 		return fmt.Errorf("failed to save movie view: %w", err)
 	}
 
+	// Keep the generated ID on the view record.
+	id, err := result.LastInsertId()
+	if err == nil {
+		view.ID = int(id)
+	}
+
 	// Return nil if successful.
 	return nil
 }
 
-// HasViewed checks if a user has already viewed a movie.
-func (r *StatsRepo) HasViewed(movieID int, userID int) (bool, error) {
+// GetViewSession retrieves a playback session by its session ID.
+func (r *StatsRepo) GetViewSession(sessionID string) (*model.MovieView, error) {
 	query := `
-		SELECT COUNT(1) 
-		FROM movie_views 
-		WHERE movie_id = ? AND user_id = ?
+		SELECT id, session_id, movie_id, user_id, visitor_id, viewed_at, last_heartbeat_at, ended_at, duration
+		FROM movie_views
+		WHERE session_id = ?
 	`
 
-	// Execute the query and check the count.
-	var count int
-	err := r.DB.QueryRow(query, movieID, userID).Scan(&count)
+	return scanViewSession(r.DB.QueryRow(query, sessionID))
+}
+
+// GetRecentVisitorView retrieves the latest session of an anonymous visitor for a movie started within the window.
+// Ended sessions count too, so ending and restarting playback does not record another view.
+func (r *StatsRepo) GetRecentVisitorView(movieID int, visitorID string, windowMinutes int) (*model.MovieView, error) {
+	query := `
+		SELECT id, session_id, movie_id, user_id, visitor_id, viewed_at, last_heartbeat_at, ended_at, duration
+		FROM movie_views
+		WHERE movie_id = ? AND visitor_id = ? AND viewed_at >= NOW() - INTERVAL ? MINUTE
+		ORDER BY viewed_at DESC, id DESC
+		LIMIT 1
+	`
+
+	return scanViewSession(r.DB.QueryRow(query, movieID, visitorID, windowMinutes))
+}
+
+// scanViewSession scans a movie_views row into a MovieView.
+func scanViewSession(row *sql.Row) (*model.MovieView, error) {
+	var view model.MovieView
+	var userID sql.NullInt64
+	var visitorID sql.NullString
+
+	err := row.Scan(&view.ID, &view.SessionID, &view.MovieID, &userID, &visitorID,
+		&view.ViewedAt, &view.LastHeartbeatAt, &view.EndedAt, &view.Duration)
 	if err != nil {
-		return false, fmt.Errorf("failed to check view status: %w", err)
+		return nil, err
 	}
 
-	// Return true if the user has viewed the movie, else false.
-	return count > 0, nil
+	view.UserID = int(userID.Int64)
+	view.VisitorID = visitorID.String
+
+	return &view, nil
+}
+
+// watchedSecondsIncrement adds the reported seconds to a session, capped at the wall-clock time since its
+// previous heartbeat or its start. It must be assigned before last_heartbeat_at is updated.
+const watchedSecondsIncrement = "duration + GREATEST(0, LEAST(?, TIMESTAMPDIFF(SECOND, COALESCE(last_heartbeat_at, viewed_at), NOW())))"
+
+// HeartbeatViewSession adds watched seconds to an open playback session.
+func (r *StatsRepo) HeartbeatViewSession(sessionID string, duration int) error {
+	query := `
+		UPDATE movie_views
+		SET duration = ` + watchedSecondsIncrement + `, last_heartbeat_at = NOW()
+		WHERE session_id = ? AND ended_at IS NULL
+	`
+
+	result, err := r.DB.Exec(query, duration, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no open session updated, check session_id")
+	}
+
+	return nil
+}
+
+// EndViewSession closes an open playback session, adding the final watched seconds with the same cap
+// as HeartbeatViewSession.
+func (r *StatsRepo) EndViewSession(sessionID string, duration int) error {
+	query := `
+		UPDATE movie_views
+		SET duration = ` + watchedSecondsIncrement + `, last_heartbeat_at = NOW(), ended_at = NOW()
+		WHERE session_id = ? AND ended_at IS NULL
+	`
+
+	result, err := r.DB.Exec(query, duration, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no open session updated, check session_id")
+	}
+
+	return nil
 }
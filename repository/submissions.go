@@ -0,0 +1,400 @@
+package repository
+
+import (
+	"database/sql"
+	"movies/model"
+)
+
+type SubmissionsRepo struct {
+	DB *sql.DB
+}
+
+func NewSubmissionsRepo(DB *sql.DB) *SubmissionsRepo {
+	return &SubmissionsRepo{DB: DB}
+}
+
+// submissionSelect selects submissions joined with the filmmaker's display name.
+const submissionSelect = `
+	SELECT fs.id, fs.user_id, COALESCE(u.display_name, ''), fs.edition_id, fs.section_id, fs.title,
+		COALESCE(fs.description, ''), COALESCE(fs.duration, ''), COALESCE(fs.artist, ''), fs.genre_id,
+		fs.watch_url, fs.status, fs.movie_id, fs.created_at, fs.updated_at
+	FROM film_submissions fs
+	INNER JOIN users u ON u.id = fs.user_id
+`
+
+// scanSubmission scans a row selected with submissionSelect.
+func scanSubmission(row interface{ Scan(...interface{}) error }) (*model.Submission, error) {
+	var submission model.Submission
+	err := row.Scan(&submission.ID, &submission.UserID, &submission.SubmitterName, &submission.EditionID, &submission.SectionID,
+		&submission.Title, &submission.Description, &submission.Duration, &submission.Artist, &submission.GenreID,
+		&submission.WatchURL, &submission.Status, &submission.MovieID, &submission.CreatedAt, &submission.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &submission, nil
+}
+
+// querySubmissions runs a query built on submissionSelect and scans every row.
+func (r *SubmissionsRepo) querySubmissions(query string, args ...interface{}) ([]model.Submission, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	submissions := []model.Submission{}
+	for rows.Next() {
+		submission, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, *submission)
+	}
+
+	return submissions, rows.Err()
+}
+
+// Create inserts a new submission and sets its generated ID.
+func (r *SubmissionsRepo) Create(submission *model.Submission) error {
+	query := `
+		INSERT INTO film_submissions (user_id, edition_id, section_id, title, description, duration, artist, genre_id, watch_url)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.DB.Exec(query, submission.UserID, submission.EditionID, submission.SectionID, submission.Title,
+		submission.Description, submission.Duration, submission.Artist, submission.GenreID, submission.WatchURL)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	submission.ID = int(id)
+
+	return nil
+}
+
+// GetSubmission retrieves a submission by ID. It returns sql.ErrNoRows when the submission does not exist.
+func (r *SubmissionsRepo) GetSubmission(submissionID int) (*model.Submission, error) {
+	return scanSubmission(r.DB.QueryRow(submissionSelect+" WHERE fs.id = ?", submissionID))
+}
+
+// ListUserSubmissions retrieves the submissions of a filmmaker, newest first.
+func (r *SubmissionsRepo) ListUserSubmissions(userID int) ([]model.Submission, error) {
+	return r.querySubmissions(submissionSelect+" WHERE fs.user_id = ? ORDER BY fs.created_at DESC, fs.id DESC", userID)
+}
+
+// ListSubmissions retrieves submissions oldest first, so they are reviewed in order of arrival.
+// A non-zero editionID and a non-empty status narrow the list.
+func (r *SubmissionsRepo) ListSubmissions(editionID int, status string) ([]model.Submission, error) {
+	query := submissionSelect + `
+		WHERE (? = 0 OR fs.edition_id = ?) AND (? = '' OR fs.status = ?)
+		ORDER BY fs.created_at ASC, fs.id ASC
+	`
+	return r.querySubmissions(query, editionID, editionID, status, status)
+}
+
+// UpdateStatus moves a submission from one status to another unless it was converted into a movie.
+// It reports whether the submission was still in the expected status.
+func (r *SubmissionsRepo) UpdateStatus(submissionID int, from, to string) (bool, error) {
+	result, err := r.DB.Exec("UPDATE film_submissions SET status = ? WHERE id = ? AND status = ? AND movie_id IS NULL", to, submissionID, from)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// ListNotes retrieves the notes of a submission in order. With sharedOnly, internal notes are left out.
+func (r *SubmissionsRepo) ListNotes(submissionID int, sharedOnly bool) ([]model.SubmissionNote, error) {
+	query := `
+		SELECT n.id, COALESCE(u.display_name, ''), n.body, n.shared, n.created_at
+		FROM submission_notes n
+		LEFT JOIN users u ON u.id = n.author_id
+		WHERE n.submission_id = ? AND (? = 0 OR n.shared = 1)
+		ORDER BY n.created_at ASC, n.id ASC
+	`
+
+	rows, err := r.DB.Query(query, submissionID, sharedOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []model.SubmissionNote{}
+	for rows.Next() {
+		var note model.SubmissionNote
+		if err := rows.Scan(&note.ID, &note.AuthorName, &note.Body, &note.Shared, &note.CreatedAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+// CreateNote adds a programmer note to a submission.
+func (r *SubmissionsRepo) CreateNote(submissionID, authorID int, body string, shared bool) error {
+	query := "INSERT INTO submission_notes (submission_id, author_id, body, shared) VALUES (?, ?, ?, ?)"
+	_, err := r.DB.Exec(query, submissionID, authorID, body, shared)
+	return err
+}
+
+// Convert creates a catalogue movie from a selected submission and links the two. The submission row
+// is locked while converting, so a submission becomes at most one movie. It returns the new movie ID,
+// or 0 when the submission is no longer selected or was already converted.
+func (r *SubmissionsRepo) Convert(submissionID int) (int, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	var movieID *int
+	err = tx.QueryRow("SELECT status, movie_id FROM film_submissions WHERE id = ? FOR UPDATE", submissionID).Scan(&status, &movieID)
+	if err != nil {
+		return 0, err
+	}
+	if status != model.SubmissionStatusSelected || movieID != nil {
+		return 0, nil
+	}
+
+	query := `
+		INSERT INTO movies (title, description, duration, artist, genre_id, watch_url, edition_id, section_id)
+		SELECT title, description, duration, artist, genre_id, watch_url, edition_id, section_id
+		FROM film_submissions
+		WHERE id = ?
+	`
+	result, err := tx.Exec(query, submissionID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec("UPDATE film_submissions SET movie_id = ? WHERE id = ?", id, submissionID); err != nil {
+		return 0, err
+	}
+
+	return int(id), tx.Commit()
+}
+
+// ListAssignedSubmissions retrieves the submissions assigned to a reviewer, oldest assignment first.
+func (r *SubmissionsRepo) ListAssignedSubmissions(reviewerID int) ([]model.Submission, error) {
+	query := submissionSelect + `
+		INNER JOIN submission_reviews sr ON sr.submission_id = fs.id
+		WHERE sr.reviewer_id = ?
+		ORDER BY sr.assigned_at ASC, fs.id ASC
+	`
+	return r.querySubmissions(query, reviewerID)
+}
+
+// reviewSelect selects reviewer assignments joined with the reviewer's display name.
+const reviewSelect = `
+	SELECT sr.submission_id, sr.reviewer_id, COALESCE(u.display_name, ''), sr.score, COALESCE(sr.comment, ''),
+		sr.assigned_at, sr.reviewed_at
+	FROM submission_reviews sr
+	INNER JOIN users u ON u.id = sr.reviewer_id
+`
+
+// scanReview scans a row selected with reviewSelect.
+func scanReview(row interface{ Scan(...interface{}) error }) (*model.SubmissionReview, error) {
+	var review model.SubmissionReview
+	err := row.Scan(&review.SubmissionID, &review.ReviewerID, &review.ReviewerName, &review.Score, &review.Comment,
+		&review.AssignedAt, &review.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// queryReviews runs a query built on reviewSelect and scans every row.
+func (r *SubmissionsRepo) queryReviews(query string, args ...interface{}) ([]model.SubmissionReview, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []model.SubmissionReview{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, rows.Err()
+}
+
+// GetReview retrieves a reviewer's assignment to a submission. It returns sql.ErrNoRows when the
+// reviewer is not assigned.
+func (r *SubmissionsRepo) GetReview(submissionID, reviewerID int) (*model.SubmissionReview, error) {
+	return scanReview(r.DB.QueryRow(reviewSelect+" WHERE sr.submission_id = ? AND sr.reviewer_id = ?", submissionID, reviewerID))
+}
+
+// ListReviews retrieves the reviewer assignments of a submission in order of assignment.
+func (r *SubmissionsRepo) ListReviews(submissionID int) ([]model.SubmissionReview, error) {
+	return r.queryReviews(reviewSelect+" WHERE sr.submission_id = ? ORDER BY sr.assigned_at ASC, sr.reviewer_id ASC", submissionID)
+}
+
+// ListReviewerReviews retrieves every assignment of a reviewer.
+func (r *SubmissionsRepo) ListReviewerReviews(reviewerID int) ([]model.SubmissionReview, error) {
+	return r.queryReviews(reviewSelect+" WHERE sr.reviewer_id = ?", reviewerID)
+}
+
+// AssignReviewer assigns a reviewer to a submission. Assigning an assigned reviewer keeps their review.
+func (r *SubmissionsRepo) AssignReviewer(submissionID, reviewerID, assignedBy int) error {
+	query := `
+		INSERT INTO submission_reviews (submission_id, reviewer_id, assigned_by)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE submission_id = submission_id
+	`
+	_, err := r.DB.Exec(query, submissionID, reviewerID, assignedBy)
+	return err
+}
+
+// UnassignReviewer removes a reviewer and their review from a submission. It reports whether the
+// reviewer was assigned.
+func (r *SubmissionsRepo) UnassignReviewer(submissionID, reviewerID int) (bool, error) {
+	result, err := r.DB.Exec("DELETE FROM submission_reviews WHERE submission_id = ? AND reviewer_id = ?", submissionID, reviewerID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// SaveReview stores a reviewer's score and comment on a submission assigned to them.
+func (r *SubmissionsRepo) SaveReview(submissionID, reviewerID, score int, comment string) error {
+	query := "UPDATE submission_reviews SET score = ?, comment = ?, reviewed_at = NOW() WHERE submission_id = ? AND reviewer_id = ?"
+	_, err := r.DB.Exec(query, score, comment, submissionID, reviewerID)
+	return err
+}
+
+// HasConflict checks whether a reviewer declared a conflict of interest on a submission.
+func (r *SubmissionsRepo) HasConflict(submissionID, reviewerID int) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM submission_conflicts WHERE submission_id = ? AND reviewer_id = ?)"
+
+	var exists bool
+	err := r.DB.QueryRow(query, submissionID, reviewerID).Scan(&exists)
+	return exists, err
+}
+
+// DeclareConflict records a reviewer's conflict of interest and removes their assignment to the
+// submission in the same transaction. Declaring again replaces the reason.
+func (r *SubmissionsRepo) DeclareConflict(submissionID, reviewerID int, reason string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO submission_conflicts (submission_id, reviewer_id, reason)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE reason = VALUES(reason)
+	`
+	if _, err := tx.Exec(query, submissionID, reviewerID, reason); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM submission_reviews WHERE submission_id = ? AND reviewer_id = ?", submissionID, reviewerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListConflicts retrieves the conflicts of interest declared on a submission in order.
+func (r *SubmissionsRepo) ListConflicts(submissionID int) ([]model.SubmissionConflict, error) {
+	query := `
+		SELECT c.reviewer_id, COALESCE(u.display_name, ''), c.reason, c.declared_at
+		FROM submission_conflicts c
+		INNER JOIN users u ON u.id = c.reviewer_id
+		WHERE c.submission_id = ?
+		ORDER BY c.declared_at ASC, c.reviewer_id ASC
+	`
+
+	rows, err := r.DB.Query(query, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := []model.SubmissionConflict{}
+	for rows.Next() {
+		var conflict model.SubmissionConflict
+		if err := rows.Scan(&conflict.ReviewerID, &conflict.ReviewerName, &conflict.Reason, &conflict.DeclaredAt); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts, rows.Err()
+}
+
+// GetReviewProgress summarizes the review of an edition's submissions per programme section, with
+// submissions without a section last.
+func (r *SubmissionsRepo) GetReviewProgress(editionID int) ([]model.SubmissionSectionProgress, error) {
+	query := `
+		SELECT fs.section_id, COALESCE(ps.name, ''), COUNT(*),
+			SUM(fs.status = 'received'), SUM(fs.status = 'in_review'), SUM(fs.status = 'selected'), SUM(fs.status = 'rejected'),
+			SUM(sr.assigned IS NULL), COALESCE(SUM(sr.assigned), 0), COALESCE(SUM(sr.done), 0), COALESCE(SUM(sc.conflicts), 0),
+			SUM(sr.score_sum) / NULLIF(SUM(sr.done), 0)
+		FROM film_submissions fs
+		LEFT JOIN programme_sections ps ON ps.id = fs.section_id
+		LEFT JOIN (
+			SELECT submission_id, COUNT(*) AS assigned, COUNT(score) AS done, SUM(score) AS score_sum
+			FROM submission_reviews
+			GROUP BY submission_id
+		) sr ON sr.submission_id = fs.id
+		LEFT JOIN (
+			SELECT submission_id, COUNT(*) AS conflicts
+			FROM submission_conflicts
+			GROUP BY submission_id
+		) sc ON sc.submission_id = fs.id
+		WHERE fs.edition_id = ?
+		GROUP BY fs.section_id, ps.name
+		ORDER BY fs.section_id IS NULL, ps.name ASC
+	`
+
+	rows, err := r.DB.Query(query, editionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := []model.SubmissionSectionProgress{}
+	for rows.Next() {
+		var section model.SubmissionSectionProgress
+		err := rows.Scan(&section.SectionID, &section.SectionName, &section.Submissions,
+			&section.Received, &section.InReview, &section.Selected, &section.Rejected,
+			&section.Unassigned, &section.Assignments, &section.ReviewsDone, &section.Conflicts, &section.AverageScore)
+		if err != nil {
+			return nil, err
+		}
+		section.ReviewsPending = section.Assignments - section.ReviewsDone
+		progress = append(progress, section)
+	}
+
+	return progress, rows.Err()
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"movies/model"
+	"time"
+)
+
+type TicketsRepo struct {
+	DB *sql.DB
+}
+
+func NewTicketsRepo(DB *sql.DB) *TicketsRepo {
+	return &TicketsRepo{DB: DB}
+}
+
+// ticketSelect selects tickets with their screening details. Held tickets past their deadline are
+// reported as expired even before a reservation for the screening marks them so.
+const ticketSelect = `
+	SELECT t.id, t.screening_id, t.user_id, t.seats,
+		CASE WHEN t.status = 'held' AND t.hold_expires_at <= NOW() THEN 'expired' ELSE t.status END,
+		t.code, t.hold_expires_at, t.created_at, t.confirmed_at, t.cancelled_at, t.checked_in_at,
+		m.title, v.name, s.starts_at
+	FROM tickets t
+	INNER JOIN screenings s ON s.id = t.screening_id
+	INNER JOIN movies m ON m.id = s.movie_id
+	INNER JOIN venues v ON v.id = s.venue_id
+`
+
+// scanTicket scans a row selected with ticketSelect.
+func scanTicket(row interface{ Scan(...interface{}) error }) (*model.Ticket, error) {
+	var ticket model.Ticket
+	err := row.Scan(&ticket.ID, &ticket.ScreeningID, &ticket.UserID, &ticket.Seats, &ticket.Status,
+		&ticket.Code, &ticket.HoldExpiresAt, &ticket.CreatedAt, &ticket.ConfirmedAt, &ticket.CancelledAt, &ticket.CheckedInAt,
+		&ticket.MovieTitle, &ticket.VenueName, &ticket.StartsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+// ReserveSeats holds seats for a screening for holdMinutes if the venue has enough left, and sets the
+// ticket ID. The screening row is locked while seats are counted, so two reservations cannot both take
+// the last seats. It reports whether the seats were held and how many seats were available.
+// It returns sql.ErrNoRows when the screening does not exist.
+func (r *TicketsRepo) ReserveSeats(ticket *model.Ticket, holdMinutes int) (bool, int, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return false, 0, err
+	}
+	defer tx.Rollback()
+
+	// Lock the screening; every reservation for it waits here
+	var capacity int
+	lockQuery := `
+		SELECT v.capacity
+		FROM screenings s
+		INNER JOIN venues v ON v.id = s.venue_id
+		WHERE s.id = ?
+		FOR UPDATE OF s
+	`
+	if err := tx.QueryRow(lockQuery, ticket.ScreeningID).Scan(&capacity); err != nil {
+		return false, 0, err
+	}
+
+	// Release holds that ran out so their seats count as free
+	expireQuery := `
+		UPDATE tickets
+		SET status = 'expired'
+		WHERE screening_id = ? AND status = 'held' AND hold_expires_at <= NOW()
+	`
+	if _, err := tx.Exec(expireQuery, ticket.ScreeningID); err != nil {
+		return false, 0, err
+	}
+
+	var taken int
+	takenQuery := `
+		SELECT COALESCE(SUM(seats), 0)
+		FROM tickets
+		WHERE screening_id = ? AND status IN ('held', 'confirmed')
+	`
+	if err := tx.QueryRow(takenQuery, ticket.ScreeningID).Scan(&taken); err != nil {
+		return false, 0, err
+	}
+
+	available := capacity - taken
+	if available < 0 {
+		available = 0
+	}
+	if ticket.Seats > available {
+		return false, available, nil
+	}
+
+	insertQuery := `
+		INSERT INTO tickets (screening_id, user_id, seats, status, code, hold_expires_at)
+		VALUES (?, ?, ?, 'held', ?, NOW() + INTERVAL ? MINUTE)
+	`
+	result, err := tx.Exec(insertQuery, ticket.ScreeningID, ticket.UserID, ticket.Seats, ticket.Code, holdMinutes)
+	if err != nil {
+		return false, 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, 0, err
+	}
+	ticket.ID = int(id)
+
+	return true, available, tx.Commit()
+}
+
+// GetTicket retrieves a ticket by ID. It returns sql.ErrNoRows when the ticket does not exist.
+func (r *TicketsRepo) GetTicket(ticketID int) (*model.Ticket, error) {
+	return scanTicket(r.DB.QueryRow(ticketSelect+" WHERE t.id = ?", ticketID))
+}
+
+// ListUserTickets retrieves the tickets of a user, upcoming screenings first.
+func (r *TicketsRepo) ListUserTickets(userID int) ([]model.Ticket, error) {
+	rows, err := r.DB.Query(ticketSelect+" WHERE t.user_id = ? ORDER BY s.starts_at DESC, t.id DESC", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tickets := []model.Ticket{}
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, *ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+// ConfirmTicket turns a held ticket whose hold has not run out into a confirmed one.
+// It reports whether the ticket was confirmed.
+func (r *TicketsRepo) ConfirmTicket(ticketID int) (bool, error) {
+	query := `
+		UPDATE tickets
+		SET status = 'confirmed', confirmed_at = NOW(), hold_expires_at = NULL
+		WHERE id = ? AND status = 'held' AND hold_expires_at > NOW()
+	`
+
+	result, err := r.DB.Exec(query, ticketID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// CancelTicket cancels a held or confirmed ticket that was not checked in, releasing its seats.
+// It reports whether the ticket was cancelled.
+func (r *TicketsRepo) CancelTicket(ticketID int) (bool, error) {
+	query := `
+		UPDATE tickets
+		SET status = 'cancelled', cancelled_at = NOW(), hold_expires_at = NULL
+		WHERE id = ? AND checked_in_at IS NULL
+			AND (status = 'confirmed' OR (status = 'held' AND hold_expires_at > NOW()))
+	`
+
+	result, err := r.DB.Exec(query, ticketID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// CheckIn marks a ticket as scanned by staffID and records one screening view per seat in movie_views,
+// so physical audiences count in the statistics. The ticket row is locked, so a ticket is admitted
+// once even when it is scanned at two doors at the same time. It returns the check-in time and
+// whether this scan was the first; for a repeated scan the time is that of the original check-in.
+// Tickets that are no longer confirmed are refused with "ticket not valid".
+func (r *TicketsRepo) CheckIn(ticket *model.Ticket, staffID int, screening *model.Screening, sessionIDs []string) (time.Time, bool, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	var checkedInAt *time.Time
+	if err := tx.QueryRow("SELECT status, checked_in_at FROM tickets WHERE id = ? FOR UPDATE", ticket.ID).Scan(&status, &checkedInAt); err != nil {
+		return time.Time{}, false, err
+	}
+	if checkedInAt != nil {
+		return *checkedInAt, false, nil
+	}
+
+	// The ticket may have been cancelled since the caller loaded it
+	if status != model.TicketStatusConfirmed {
+		return time.Time{}, false, fmt.Errorf("ticket not valid")
+	}
+
+	if _, err := tx.Exec("UPDATE tickets SET checked_in_at = NOW(), checked_in_by = ? WHERE id = ?", staffID, ticket.ID); err != nil {
+		return time.Time{}, false, err
+	}
+
+	// Attendees watch the whole screening. The ticket owner takes the first seat; the other seats are
+	// guests, each its own anonymous viewer, so the owner's views are not multiplied by the seats.
+	duration := int(screening.EndsAt.Sub(screening.StartsAt).Seconds())
+	viewQuery := `
+		INSERT INTO movie_views (session_id, movie_id, user_id, visitor_id, viewed_at, ended_at, duration, source, ticket_id)
+		VALUES (?, ?, ?, ?, NOW(), ?, ?, 'screening', ?)
+	`
+	for seat, sessionID := range sessionIDs {
+		var userID, visitorID interface{}
+		if seat == 0 {
+			userID = ticket.UserID
+		} else {
+			visitorID = fmt.Sprintf("ticket-%d-seat-%d", ticket.ID, seat+1)
+		}
+
+		if _, err := tx.Exec(viewQuery, sessionID, screening.MovieID, userID, visitorID, screening.EndsAt, duration, ticket.ID); err != nil {
+			return time.Time{}, false, err
+		}
+	}
+
+	var scannedAt time.Time
+	if err := tx.QueryRow("SELECT checked_in_at FROM tickets WHERE id = ?", ticket.ID).Scan(&scannedAt); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return scannedAt, true, tx.Commit()
+}
+
+// GetAttendance counts the confirmed and checked-in seats of a screening.
+// It returns sql.ErrNoRows when the screening does not exist.
+func (r *TicketsRepo) GetAttendance(screeningID int) (*model.Attendance, error) {
+	query := `
+		SELECT v.capacity,
+			COALESCE(SUM(CASE WHEN t.status = 'confirmed' THEN t.seats END), 0),
+			COALESCE(SUM(CASE WHEN t.checked_in_at IS NOT NULL THEN t.seats END), 0)
+		FROM screenings s
+		INNER JOIN venues v ON v.id = s.venue_id
+		LEFT JOIN tickets t ON t.screening_id = s.id
+		WHERE s.id = ?
+		GROUP BY v.capacity
+	`
+
+	attendance := &model.Attendance{ScreeningID: screeningID}
+	err := r.DB.QueryRow(query, screeningID).Scan(&attendance.Capacity, &attendance.SeatsSold, &attendance.CheckedIn)
+	if err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
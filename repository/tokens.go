@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"time"
+)
+
+// ErrRefreshTokenReused is returned when a refresh token is rotated a second time.
+var ErrRefreshTokenReused = errors.New("refresh token already rotated")
+
+type TokensRepo struct {
+	DB *sql.DB
+}
+
+func NewTokensRepo(DB *sql.DB) *TokensRepo {
+	return &TokensRepo{DB: DB}
+}
+
+// CreateRefreshToken stores a new refresh token hash.
+func (r *TokensRepo) CreateRefreshToken(token *model.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (user_id, token_hash, family_id, two_factor, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := r.DB.Exec(query, token.UserID, token.TokenHash, token.FamilyID, token.TwoFactor, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	// Keep the generated ID on the token record.
+	if id, err := result.LastInsertId(); err == nil {
+		token.ID = int(id)
+	}
+
+	return nil
+}
+
+// GetRefreshToken retrieves a refresh token by the hash of its raw value.
+func (r *TokensRepo) GetRefreshToken(tokenHash string) (*model.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, family_id, two_factor, expires_at, revoked_at, replaced_by_id
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`
+
+	var token model.RefreshToken
+	err := r.DB.QueryRow(query, tokenHash).Scan(&token.ID, &token.UserID, &token.TokenHash, &token.FamilyID, &token.TwoFactor,
+		&token.ExpiresAt, &token.RevokedAt, &token.ReplacedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken stores the replacement token and revokes the old one in a single transaction.
+// It returns ErrRefreshTokenReused if the old token was already revoked, e.g. by a concurrent refresh.
+func (r *TokensRepo) RotateRefreshToken(oldID int, replacement *model.RefreshToken) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		INSERT INTO refresh_tokens (user_id, token_hash, family_id, two_factor, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, replacement.UserID, replacement.TokenHash, replacement.FamilyID, replacement.TwoFactor, replacement.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	replacement.ID = int(id)
+
+	result, err = tx.Exec(`
+		UPDATE refresh_tokens
+		SET revoked_at = NOW(), replaced_by_id = ?
+		WHERE id = ? AND revoked_at IS NULL
+	`, replacement.ID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrRefreshTokenReused
+	}
+
+	return tx.Commit()
+}
+
+// RevokeRefreshTokenFamily revokes every active token rotated from the same login.
+func (r *TokensRepo) RevokeRefreshTokenFamily(familyID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = ? AND revoked_at IS NULL
+	`
+
+	_, err := r.DB.Exec(query, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUserRefreshTokens revokes every active refresh token of a user.
+func (r *TokensRepo) RevokeUserRefreshTokens(userID int) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = ? AND revoked_at IS NULL
+	`
+
+	_, err := r.DB.Exec(query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAccessToken puts an access token ID on the revocation list until it expires.
+// Entries of tokens that have expired are purged at the same time.
+func (r *TokensRepo) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, expires_at)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE expires_at = VALUES(expires_at)
+	`
+
+	if _, err := r.DB.Exec(query, jti, expiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	if _, err := r.DB.Exec("DELETE FROM revoked_tokens WHERE expires_at < NOW()"); err != nil {
+		return fmt.Errorf("failed to purge revoked tokens: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked checks whether an access token ID is on the revocation list.
+func (r *TokensRepo) IsTokenRevoked(jti string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM revoked_tokens
+			WHERE jti = ?
+		)
+	`
+
+	var revoked bool
+	err := r.DB.QueryRow(query, jti).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// CreateUserToken stores a single-use token for a user, invalidating earlier unused tokens with the same purpose.
+func (r *TokensRepo) CreateUserToken(userID int, purpose, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE user_tokens
+		SET used_at = NOW()
+		WHERE user_id = ? AND purpose = ? AND used_at IS NULL
+	`, userID, purpose)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO user_tokens (user_id, purpose, token_hash, expires_at)
+		VALUES (?, ?, ?, ?)
+	`, userID, purpose, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ConsumeUserToken marks an unused, unexpired token as used and returns its user ID.
+// It returns sql.ErrNoRows when the token is unknown, expired, already used, or for another purpose.
+func (r *TokensRepo) ConsumeUserToken(tokenHash, purpose string) (int, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var tokenID, userID int
+	err = tx.QueryRow(`
+		SELECT id, user_id
+		FROM user_tokens
+		WHERE token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > NOW()
+		FOR UPDATE
+	`, tokenHash, purpose).Scan(&tokenID, &userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec("UPDATE user_tokens SET used_at = NOW() WHERE id = ?", tokenID); err != nil {
+		return 0, fmt.Errorf("failed to consume user token: %w", err)
+	}
+
+	return userID, tx.Commit()
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"database/sql"
+)
+
+// SetPendingTOTPSecret stores a new TOTP secret that becomes active once EnableTOTP confirms it.
+func (ur *UsersRepo) SetPendingTOTPSecret(userID int, secret string) error {
+	query := "UPDATE users SET totp_secret = ?, totp_last_step = NULL WHERE id = ? AND totp_enabled_at IS NULL"
+	_, err := ur.DB.Exec(query, secret, userID)
+	return err
+}
+
+// EnableTOTP turns two-factor authentication on and replaces the user's recovery codes in one transaction.
+func (ur *UsersRepo) EnableTOTP(userID int, step int64, recoveryCodeHashes []string) error {
+	tx, err := ur.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE users SET totp_enabled_at = NOW(), totp_last_step = ? WHERE id = ? AND totp_secret IS NOT NULL"
+	if _, err := tx.Exec(query, step, userID); err != nil {
+		return err
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DisableTOTP turns two-factor authentication off and deletes the secret and recovery codes.
+func (ur *UsersRepo) DisableTOTP(userID int) error {
+	tx, err := ur.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_step = NULL WHERE id = ?"
+	if _, err := tx.Exec(query, userID); err != nil {
+		return err
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseTOTPStep records an accepted TOTP time step. It returns false when the step is not newer than
+// the last accepted one, i.e. the code was already used.
+func (ur *UsersRepo) UseTOTPStep(userID int, step int64) (bool, error) {
+	query := "UPDATE users SET totp_last_step = ? WHERE id = ? AND (totp_last_step IS NULL OR totp_last_step < ?)"
+	result, err := ur.DB.Exec(query, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	return rowsAffected > 0, err
+}
+
+// ReplaceRecoveryCodes swaps the user's recovery codes for a new set.
+func (ur *UsersRepo) ReplaceRecoveryCodes(userID int, codeHashes []string) error {
+	tx, err := ur.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used. It returns false if no such code exists.
+func (ur *UsersRepo) UseRecoveryCode(userID int, codeHash string) (bool, error) {
+	query := "UPDATE recovery_codes SET used_at = NOW() WHERE user_id = ? AND code_hash = ? AND used_at IS NULL"
+	result, err := ur.DB.Exec(query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	return rowsAffected > 0, err
+}
+
+// CountRecoveryCodes counts the unused recovery codes of a user.
+func (ur *UsersRepo) CountRecoveryCodes(userID int) (int, error) {
+	var count int
+	err := ur.DB.QueryRow("SELECT COUNT(*) FROM recovery_codes WHERE user_id = ? AND used_at IS NULL", userID).Scan(&count)
+	return count, err
+}
+
+// replaceRecoveryCodes deletes the user's recovery codes and inserts the given hashes within a transaction.
+func replaceRecoveryCodes(tx *sql.Tx, userID int, codeHashes []string) error {
+	if _, err := tx.Exec("DELETE FROM recovery_codes WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		if _, err := tx.Exec("INSERT INTO recovery_codes (user_id, code_hash) VALUES (?, ?)", userID, codeHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 	"movies/model"
 )
@@ -17,10 +18,10 @@ func NewUsersRepo(DB *sql.DB) *UsersRepo {
 // Create inserts a new user into the users table.
 func (ur *UsersRepo) Create(user *model.Users) (*model.Users, error) {
 	// SQL query to insert a new user record into the database.
-	sql_insert := "INSERT INTO users (email, password, gender, role) VALUES (?,?,?,?)"
+	sql_insert := "INSERT INTO users (email, password, gender, role, registration_ip) VALUES (?,?,?,?,?)"
 
 	// Execute the insert query with the provided user details.
-	_, err := ur.DB.Exec(sql_insert, user.Email, user.Password, user.Gender, user.Role)
+	result, err := ur.DB.Exec(sql_insert, user.Email, user.Password, user.Gender, user.Role, user.RegistrationIP)
 
 	// If there was an error executing the query, log it and return the error.
 	if err != nil {
@@ -28,6 +29,11 @@ func (ur *UsersRepo) Create(user *model.Users) (*model.Users, error) {
 		return nil, err
 	}
 
+	// Keep the generated ID on the user record.
+	if id, err := result.LastInsertId(); err == nil {
+		user.Id = int(id)
+	}
+
 	// Return the user object if insertion was successful.
 	return user, nil
 }
@@ -55,14 +61,20 @@ func (ur *UsersRepo) IsEmailExists(email string) (bool, error) {
 // GetUser retrieves a user record based on the provided email.
 func (ur *UsersRepo) GetUser(user *model.Users) (*model.Users, error) {
 	// SQL query to retrieve a user based on the email.
-	sql_query := "SELECT id, email, password, gender, role FROM users WHERE email = ?"
+	sql_query := `
+		SELECT id, email, password, COALESCE(gender, ''), role, COALESCE(display_name, ''), COALESCE(avatar_url, ''),
+			created_at, disabled_at, email_verified_at, COALESCE(totp_secret, ''), totp_enabled_at
+		FROM users
+		WHERE email = ?
+	`
 	var result model.Users
 
 	// Execute the query to get the user data.
 	row := ur.DB.QueryRow(sql_query, user.Email)
 
 	// Scan the result into the result model.
-	if err := row.Scan(&result.Id, &result.Email, &result.Password, &result.Gender, &result.Role); err != nil {
+	if err := row.Scan(&result.Id, &result.Email, &result.Password, &result.Gender, &result.Role, &result.DisplayName, &result.AvatarURL,
+		&result.CreatedAt, &result.DisabledAt, &result.EmailVerifiedAt, &result.TOTPSecret, &result.TOTPEnabledAt); err != nil {
 		// If no rows were found (email does not exist), return an ErrNoRows error.
 		if err == sql.ErrNoRows {
 			log.Println("Email not found: ", user.Email)
@@ -77,3 +89,186 @@ func (ur *UsersRepo) GetUser(user *model.Users) (*model.Users, error) {
 	// Return the user if found.
 	return &result, nil
 }
+
+// GetUserByID retrieves a user record by ID.
+func (ur *UsersRepo) GetUserByID(userID int) (*model.Users, error) {
+	sql_query := `
+		SELECT id, email, password, COALESCE(gender, ''), role, COALESCE(display_name, ''), COALESCE(avatar_url, ''),
+			created_at, disabled_at, email_verified_at, COALESCE(totp_secret, ''), totp_enabled_at
+		FROM users
+		WHERE id = ?
+	`
+	var result model.Users
+
+	// Execute the query and scan the result into the result model.
+	row := ur.DB.QueryRow(sql_query, userID)
+	if err := row.Scan(&result.Id, &result.Email, &result.Password, &result.Gender, &result.Role, &result.DisplayName, &result.AvatarURL,
+		&result.CreatedAt, &result.DisabledAt, &result.EmailVerifiedAt, &result.TOTPSecret, &result.TOTPEnabledAt); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListUsers retrieves user accounts with pagination for admins.
+func (ur *UsersRepo) ListUsers(page, limit int) ([]model.UserSummary, error) {
+	offset := (page - 1) * limit
+	sql_query := `
+		SELECT id, email, COALESCE(gender, ''), role, flagged, created_at, disabled_at
+		FROM users
+		ORDER BY id ASC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := ur.DB.Query(sql_query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []model.UserSummary
+	for rows.Next() {
+		var user model.UserSummary
+		if err := rows.Scan(&user.Id, &user.Email, &user.Gender, &user.Role, &user.Flagged, &user.CreatedAt, &user.DisabledAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// UpdateRole changes the role of a user. It returns sql.ErrNoRows if the user does not exist.
+func (ur *UsersRepo) UpdateRole(userID int, role string) error {
+	result, err := ur.DB.Exec("UPDATE users SET role = ? WHERE id = ?", role, userID)
+	if err != nil {
+		return err
+	}
+
+	return requireUserRow(ur.DB, result, userID)
+}
+
+// SetDisabled disables or re-enables a user account. It returns sql.ErrNoRows if the user does not exist.
+func (ur *UsersRepo) SetDisabled(userID int, disabled bool) error {
+	sql_update := "UPDATE users SET disabled_at = NULL WHERE id = ?"
+	if disabled {
+		sql_update = "UPDATE users SET disabled_at = COALESCE(disabled_at, NOW()) WHERE id = ?"
+	}
+
+	result, err := ur.DB.Exec(sql_update, userID)
+	if err != nil {
+		return err
+	}
+
+	return requireUserRow(ur.DB, result, userID)
+}
+
+// IsUserDisabled checks whether a user account has been disabled or no longer exists.
+func (ur *UsersRepo) IsUserDisabled(userID int) (bool, error) {
+	sql_query := "SELECT disabled_at IS NOT NULL FROM users WHERE id = ?"
+
+	var disabled bool
+	err := ur.DB.QueryRow(sql_query, userID).Scan(&disabled)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return disabled, nil
+}
+
+// AdminExists checks whether at least one admin account exists.
+func (ur *UsersRepo) AdminExists() (bool, error) {
+	var exists bool
+	err := ur.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE role = 'admin')").Scan(&exists)
+	return exists, err
+}
+
+// requireUserRow turns an update that matched no row into sql.ErrNoRows.
+// MySQL reports unchanged rows as unaffected, so existence is checked separately.
+func requireUserRow(db *sql.DB, result sql.Result, userID int) error {
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = ?)", userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// MarkEmailVerified records that the user confirmed their email address.
+func (ur *UsersRepo) MarkEmailVerified(userID int) error {
+	_, err := ur.DB.Exec("UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()) WHERE id = ?", userID)
+	return err
+}
+
+// UpdatePassword stores a new password hash for the user.
+func (ur *UsersRepo) UpdatePassword(userID int, passwordHash string) error {
+	_, err := ur.DB.Exec("UPDATE users SET password = ? WHERE id = ?", passwordHash, userID)
+	return err
+}
+
+// UpdateProfile updates the given profile columns of a user.
+func (ur *UsersRepo) UpdateProfile(userID int, updates map[string]interface{}) error {
+	// Start building the UPDATE query string.
+	query := "UPDATE users SET "
+	params := []interface{}{}
+
+	// Add each column to the update query dynamically.
+	for column, value := range updates {
+		query += fmt.Sprintf("%s = ?, ", column)
+		params = append(params, value)
+	}
+
+	// Remove the trailing comma and finalize the query.
+	query = query[:len(query)-2]
+	query += " WHERE id = ?"
+	params = append(params, userID)
+
+	result, err := ur.DB.Exec(query, params...)
+	if err != nil {
+		return err
+	}
+
+	return requireUserRow(ur.DB, result, userID)
+}
+
+// CountActiveAdmins counts admin accounts that are not disabled.
+func (ur *UsersRepo) CountActiveAdmins() (int, error) {
+	var count int
+	err := ur.DB.QueryRow("SELECT COUNT(*) FROM users WHERE role = 'admin' AND disabled_at IS NULL").Scan(&count)
+	return count, err
+}
+
+// DeleteUser removes a user account together with its votes.
+// Views and tokens are removed by their ON DELETE CASCADE foreign keys;
+// votes are deleted explicitly so databases created before the cascade was added behave the same.
+func (ur *UsersRepo) DeleteUser(userID int) error {
+	tx, err := ur.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM user_votes WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec("DELETE FROM users WHERE id = ?", userID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AdminRoutes(r *gin.RouterGroup, UserHandler *handler.UsersHandler, AbuseHandler *handler.AbuseHandler) {
+	admin := r.Group("/admin", middleware.AuthMiddleware())
+	{
+		admin.GET("/roles", middleware.RequirePermission("users:read"), UserHandler.ListRoles)                            // List roles and their permissions
+		admin.GET("/users", middleware.RequirePermission("users:read"), UserHandler.ListUsers)                            // List users
+		admin.PATCH("/users/:user_id/role", middleware.RequirePermission("users:manage"), UserHandler.UpdateUserRole)     // Assign a role to a user
+		admin.POST("/users/:user_id/disable", middleware.RequirePermission("users:manage"), UserHandler.DisableUser)      // Disable an account
+		admin.POST("/users/:user_id/enable", middleware.RequirePermission("users:manage"), UserHandler.EnableUser)        // Re-enable an account
+		admin.GET("/locked-accounts", middleware.RequirePermission("users:read"), UserHandler.ListLockedAccounts)         // List accounts and IPs locked out after failed logins
+		admin.POST("/users/:user_id/unlock", middleware.RequirePermission("users:manage"), UserHandler.UnlockUser)        // Lift an account lockout
+		admin.POST("/locked-ips/:ip/unlock", middleware.RequirePermission("users:manage"), UserHandler.UnlockIP)          // Lift an IP lockout
+		admin.GET("/abuse-flags", middleware.RequirePermission("abuse:review"), AbuseHandler.ListFlags)                   // List flagged events
+		admin.POST("/abuse-flags/:flag_id/review", middleware.RequirePermission("abuse:review"), AbuseHandler.ReviewFlag) // Confirm or dismiss a flag
+	}
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AwardRoutes(r *gin.RouterGroup, AwardsHandler *handler.AwardsHandler) {
+	awards := r.Group("/awards")
+	{
+		awards.GET("", AwardsHandler.ListAwards) // Public route to list awards; winners appear once published
+
+		categories := awards.Group("/categories", middleware.AuthMiddleware(), middleware.RequirePermission("awards:manage"))
+		{
+			categories.GET("", AwardsHandler.ListCategories)                                         // List awards with unpublished winners
+			categories.POST("", AwardsHandler.CreateCategory)                                        // Create an award
+			categories.GET("/:category_id", AwardsHandler.GetCategory)                               // Get an award with unpublished winners
+			categories.PUT("/:category_id", AwardsHandler.UpdateCategory)                            // Update an unpublished award
+			categories.DELETE("/:category_id", AwardsHandler.DeleteCategory)                         // Delete an unpublished award
+			categories.POST("/:category_id/nominations", AwardsHandler.SaveNomination)               // Nominate a movie
+			categories.DELETE("/:category_id/nominations/:movie_id", AwardsHandler.DeleteNomination) // Withdraw a nomination
+			categories.PUT("/:category_id/winners", AwardsHandler.SetWinners)                        // Choose the winners among the nominees
+			categories.POST("/:category_id/audience", AwardsHandler.ComputeAudienceAward)            // Compute an audience award from the votes
+			categories.POST("/:category_id/publish", AwardsHandler.PublishCategory)                  // Announce the winners now or later
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BallotRoutes(r *gin.RouterGroup, BallotsHandler *handler.BallotsHandler) {
+	ballots := r.Group("/ballots", middleware.AuthMiddleware())
+	{
+		ballots.POST("/:movie_id", BallotsHandler.CastBallot)                                         // Attendees vote for the audience award
+		ballots.GET("/mine", BallotsHandler.ListMyBallots)                                            // Users list their ballots
+		ballots.GET("/tally", middleware.RequirePermission("awards:manage"), BallotsHandler.GetTally) // Award managers follow the audience tally
+	}
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func FestivalRoutes(r *gin.RouterGroup, FestivalHandler *handler.FestivalHandler) {
+	editions := r.Group("/editions")
+	{
+		editions.GET("", FestivalHandler.ListEditions)                                                                                                                    // Public route to list festival editions
+		editions.GET("/:edition_id", FestivalHandler.GetEdition)                                                                                                          // Public route to get an edition with its sections
+		editions.POST("", middleware.AuthMiddleware(), middleware.RequirePermission("festival:manage"), FestivalHandler.CreateEdition)                                    // Curators can create editions
+		editions.PUT("/:edition_id", middleware.AuthMiddleware(), middleware.RequirePermission("festival:manage"), FestivalHandler.UpdateEdition)                         // Curators can update editions
+		editions.DELETE("/:edition_id", middleware.AuthMiddleware(), middleware.RequirePermission("festival:manage"), FestivalHandler.DeleteEdition)                      // Curators can delete editions without movies
+		editions.POST("/:edition_id/sections", middleware.AuthMiddleware(), middleware.RequirePermission("festival:manage"), FestivalHandler.CreateSection)               // Curators can add programme sections
+		editions.PUT("/:edition_id/sections/:section_id", middleware.AuthMiddleware(), middleware.RequirePermission("festival:manage"), FestivalHandler.UpdateSection)    // Curators can update programme sections
+		editions.DELETE("/:edition_id/sections/:section_id", middleware.AuthMiddleware(), middleware.RequirePermission("festival:manage"), FestivalHandler.DeleteSection) // Curators can delete programme sections
+	}
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func JuryRoutes(r *gin.RouterGroup, JuryHandler *handler.JuryHandler) {
+	juries := r.Group("/juries", middleware.AuthMiddleware())
+	{
+		juries.GET("", middleware.RequirePermission("jury:manage"), JuryHandler.ListPanels)                                          // Admins list every panel
+		juries.POST("", middleware.RequirePermission("jury:manage"), JuryHandler.CreatePanel)                                        // Admins set up the jury of a section
+		juries.GET("/mine", middleware.RequirePermission("jury:score"), JuryHandler.ListMyPanels)                                    // Jurors list their panels
+		juries.GET("/:panel_id", JuryHandler.GetPanel)                                                                               // Jurors of the panel and admins see its details
+		juries.DELETE("/:panel_id", middleware.RequirePermission("jury:manage"), JuryHandler.DeletePanel)                            // Admins delete open panels
+		juries.PUT("/:panel_id/members/:user_id", middleware.RequirePermission("jury:manage"), JuryHandler.SaveMember)               // Admins add jurors and appoint the president
+		juries.DELETE("/:panel_id/members/:user_id", middleware.RequirePermission("jury:manage"), JuryHandler.DeleteMember)          // Admins remove jurors
+		juries.POST("/:panel_id/criteria", middleware.RequirePermission("jury:manage"), JuryHandler.CreateCriterion)                 // Admins add scoring criteria
+		juries.DELETE("/:panel_id/criteria/:criterion_id", middleware.RequirePermission("jury:manage"), JuryHandler.DeleteCriterion) // Admins remove scoring criteria
+		juries.GET("/:panel_id/scores", middleware.RequirePermission("jury:score"), JuryHandler.ListMyScores)                        // Jurors read their own scores
+		juries.PUT("/:panel_id/scores/:movie_id", middleware.RequirePermission("jury:score"), JuryHandler.SaveScores)                // Jurors score a movie until the panel is locked
+		juries.GET("/:panel_id/rankings", JuryHandler.GetRankings)                                                                   // The president and admins see the aggregate ranking
+		juries.POST("/:panel_id/lock", JuryHandler.LockPanel)                                                                        // The president or an admin finalizes the scores
+	}
+}
@@ -7,12 +7,20 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func MovieRoutes(r *gin.RouterGroup, MoviesHandler *handler.MoviesHandler) {
+func MovieRoutes(r *gin.RouterGroup, MoviesHandler *handler.MoviesHandler, AvailabilityHandler *handler.AvailabilityHandler) {
 	movies := r.Group("/movies")
 	{
-		movies.POST("", middleware.AuthMiddleware(), middleware.RoleMiddleware("admin"), MoviesHandler.Create)               // Admin can create movies
-		movies.PUT("/:movie_id", middleware.AuthMiddleware(), middleware.RoleMiddleware("admin"), MoviesHandler.UpdateMovie) // Admin can update movies
-		movies.GET("", MoviesHandler.GetAllMoviesWithPagination)                                                             // Public route to get all movies
-		movies.GET("/search", MoviesHandler.SearchMovies)                                                                    // Public route to search movies
+		movies.POST("", middleware.AuthMiddleware(), middleware.RequirePermission("movies:write"), MoviesHandler.Create)               // Curators can create movies
+		movies.PUT("/:movie_id", middleware.AuthMiddleware(), middleware.RequirePermission("movies:write"), MoviesHandler.UpdateMovie) // Curators can update movies
+		movies.GET("", middleware.OptionalAuthMiddleware(), MoviesHandler.GetAllMoviesWithPagination)                                  // Public route to get available movies
+		movies.GET("/search", middleware.OptionalAuthMiddleware(), MoviesHandler.SearchMovies)                                         // Public route to search available movies
+		movies.GET("/:movie_id/watch", MoviesHandler.Watch)                                                                            // Public route to stream a movie where it is available
+
+		availability := movies.Group("/:movie_id/availability", middleware.AuthMiddleware(), middleware.RequirePermission("movies:write"))
+		{
+			availability.GET("", AvailabilityHandler.GetAvailability)       // Curators read the availability rules
+			availability.PUT("", AvailabilityHandler.SaveAvailability)      // Curators set the window, countries and viewer limit
+			availability.DELETE("", AvailabilityHandler.DeleteAvailability) // Curators make a movie available everywhere
+		}
 	}
 }
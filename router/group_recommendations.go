@@ -0,0 +1,13 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RecommendationRoutes(r *gin.RouterGroup, RecommendationsHandler *handler.RecommendationsHandler) {
+	r.GET("/movies/:movie_id/similar", middleware.OptionalAuthMiddleware(), RecommendationsHandler.GetSimilarMovies) // Public route to get similar available movies
+	r.GET("/user/recommendations", middleware.AuthMiddleware(), RecommendationsHandler.GetUserRecommendations)       // Authenticated users get personal recommendations
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ScreeningRoutes(r *gin.RouterGroup, ScreeningsHandler *handler.ScreeningsHandler) {
+	r.GET("/schedule", ScreeningsHandler.GetSchedule) // Public route to get the screenings of a day
+
+	venues := r.Group("/venues")
+	{
+		venues.GET("", ScreeningsHandler.ListVenues)                                                                                               // Public route to list venues
+		venues.POST("", middleware.AuthMiddleware(), middleware.RequirePermission("screenings:manage"), ScreeningsHandler.CreateVenue)             // Curators can create venues
+		venues.PUT("/:venue_id", middleware.AuthMiddleware(), middleware.RequirePermission("screenings:manage"), ScreeningsHandler.UpdateVenue)    // Curators can update venues
+		venues.DELETE("/:venue_id", middleware.AuthMiddleware(), middleware.RequirePermission("screenings:manage"), ScreeningsHandler.DeleteVenue) // Curators can delete venues without screenings
+	}
+
+	screenings := r.Group("/screenings")
+	{
+		screenings.GET("/:screening_id", ScreeningsHandler.GetScreening)                                                                                       // Public route to get a screening
+		screenings.POST("", middleware.AuthMiddleware(), middleware.RequirePermission("screenings:manage"), ScreeningsHandler.CreateScreening)                 // Curators can schedule screenings
+		screenings.PUT("/:screening_id", middleware.AuthMiddleware(), middleware.RequirePermission("screenings:manage"), ScreeningsHandler.UpdateScreening)    // Curators can move screenings
+		screenings.DELETE("/:screening_id", middleware.AuthMiddleware(), middleware.RequirePermission("screenings:manage"), ScreeningsHandler.DeleteScreening) // Curators can cancel screenings
+	}
+}
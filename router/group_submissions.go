@@ -0,0 +1,28 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SubmissionRoutes(r *gin.RouterGroup, SubmissionsHandler *handler.SubmissionsHandler) {
+	submissions := r.Group("/submissions", middleware.AuthMiddleware())
+	{
+		submissions.POST("", middleware.RequirePermission("submissions:create"), SubmissionsHandler.Submit)                                               // Filmmakers submit films
+		submissions.GET("/mine", middleware.RequirePermission("submissions:create"), SubmissionsHandler.ListMySubmissions)                                // Filmmakers follow their submissions
+		submissions.GET("/mine/:submission_id", middleware.RequirePermission("submissions:create"), SubmissionsHandler.GetMySubmission)                   // Filmmakers read shared programmer notes
+		submissions.GET("", middleware.RequirePermission("submissions:review"), SubmissionsHandler.ListSubmissions)                                       // Programmers list submissions
+		submissions.GET("/assigned", middleware.RequirePermission("submissions:review"), SubmissionsHandler.ListAssigned)                                 // Reviewers follow their queue
+		submissions.GET("/dashboard", middleware.RequirePermission("submissions:assign"), SubmissionsHandler.GetDashboard)                                // Review leads follow progress per section
+		submissions.GET("/:submission_id", middleware.RequirePermission("submissions:review"), SubmissionsHandler.GetSubmission)                          // Programmers read a submission with all notes
+		submissions.PUT("/:submission_id/status", middleware.RequirePermission("submissions:review"), SubmissionsHandler.UpdateStatus)                    // Programmers move submissions through review
+		submissions.POST("/:submission_id/notes", middleware.RequirePermission("submissions:review"), SubmissionsHandler.AddNote)                         // Programmers add notes
+		submissions.PUT("/:submission_id/review", middleware.RequirePermission("submissions:review"), SubmissionsHandler.SaveReview)                      // Assigned reviewers score a film
+		submissions.POST("/:submission_id/conflict", middleware.RequirePermission("submissions:review"), SubmissionsHandler.DeclareConflict)              // Reviewers declare conflicts of interest
+		submissions.PUT("/:submission_id/reviewers/:user_id", middleware.RequirePermission("submissions:assign"), SubmissionsHandler.AssignReviewer)      // Review leads assign reviewers
+		submissions.DELETE("/:submission_id/reviewers/:user_id", middleware.RequirePermission("submissions:assign"), SubmissionsHandler.UnassignReviewer) // Review leads remove reviewers
+		submissions.POST("/:submission_id/convert", middleware.RequirePermission("movies:write"), SubmissionsHandler.Convert)                             // Selected films become catalogue movies
+	}
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"movies/handler"
+	"movies/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TicketRoutes(r *gin.RouterGroup, TicketsHandler *handler.TicketsHandler) {
+	r.POST("/screenings/:screening_id/tickets", middleware.AuthMiddleware(), TicketsHandler.ReserveSeats) // Authenticated users can hold seats
+
+	r.POST("/checkin", middleware.AuthMiddleware(), middleware.RequirePermission("tickets:checkin"), TicketsHandler.CheckIn)                                            // Volunteers scan tickets at the door
+	r.GET("/screenings/:screening_id/attendance", middleware.AuthMiddleware(), middleware.RequirePermission("tickets:checkin"), TicketsHandler.GetAttendance)           // Volunteers follow attendance
+	r.GET("/screenings/:screening_id/attendance/stream", middleware.AuthMiddleware(), middleware.RequirePermission("tickets:checkin"), TicketsHandler.StreamAttendance) // Live attendance as server-sent events
+
+	tickets := r.Group("/tickets", middleware.AuthMiddleware())
+	{
+		tickets.GET("", TicketsHandler.ListTickets)                       // List the user's tickets
+		tickets.GET("/:ticket_id", TicketsHandler.GetTicket)              // Get a ticket with its QR payload
+		tickets.POST("/:ticket_id/confirm", TicketsHandler.ConfirmTicket) // Confirm held seats
+		tickets.POST("/:ticket_id/cancel", TicketsHandler.CancelTicket)   // Release the seats
+	}
+}
@@ -2,14 +2,35 @@ package router
 
 import (
 	"movies/handler"
+	"movies/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func UserRoutes(r *gin.RouterGroup, UserHandler *handler.UsersHandler) {
+func UserRoutes(r *gin.RouterGroup, UserHandler *handler.UsersHandler, OIDCHandler *handler.OIDCHandler) {
 	user := r.Group("/user")
 	{
-		user.POST("/register", UserHandler.Register) // Register a new user
-		user.POST("/login", UserHandler.Login)       // Login an existing user
+		user.POST("/register", UserHandler.Register)                          // Register a new user
+		user.POST("/login", UserHandler.Login)                                // Login an existing user
+		user.POST("/login/2fa", UserHandler.LoginTwoFactor)                   // Complete a login with a TOTP or recovery code
+		user.POST("/refresh", UserHandler.Refresh)                            // Exchange a refresh token for new tokens
+		user.POST("/logout", middleware.AuthMiddleware(), UserHandler.Logout) // Revoke the current tokens
+		user.POST("/verify", UserHandler.VerifyEmail)                         // Confirm an email address
+		user.POST("/password/forgot", UserHandler.ForgotPassword)             // Email a password reset token
+		user.POST("/password/reset", UserHandler.ResetPassword)               // Set a new password with a reset token
+		user.GET("/oidc/login", OIDCHandler.Login)                            // Start a login at the identity provider
+		user.GET("/oidc/callback", OIDCHandler.Callback)                      // Finish an identity provider login
+
+		me := user.Group("/me", middleware.AuthMiddleware())
+		{
+			me.GET("", UserHandler.GetMe)                                       // Get the logged-in user's profile
+			me.PATCH("", UserHandler.UpdateMe)                                  // Update gender, display name or avatar
+			me.POST("/password", UserHandler.ChangePassword)                    // Change the password
+			me.DELETE("", UserHandler.DeleteMe)                                 // Delete the account
+			me.POST("/2fa/setup", UserHandler.SetupTwoFactor)                   // Start TOTP enrolment
+			me.POST("/2fa/enable", UserHandler.EnableTwoFactor)                 // Confirm enrolment and get recovery codes
+			me.POST("/2fa/disable", UserHandler.DisableTwoFactor)               // Turn two-factor authentication off
+			me.POST("/2fa/recovery-codes", UserHandler.RegenerateRecoveryCodes) // Replace the recovery codes
+		}
 	}
 }
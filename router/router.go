@@ -2,19 +2,34 @@ package router
 
 import (
 	"movies/handler"
+	"movies/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Router(MoviesHandler *handler.MoviesHandler, StatsHandler *handler.StatsHandler, UserHandler *handler.UsersHandler) *gin.Engine {
-	r := gin.Default()
+func Router(MoviesHandler *handler.MoviesHandler, AvailabilityHandler *handler.AvailabilityHandler, StatsHandler *handler.StatsHandler, UserHandler *handler.UsersHandler, AbuseHandler *handler.AbuseHandler, RecommendationsHandler *handler.RecommendationsHandler, OIDCHandler *handler.OIDCHandler, FestivalHandler *handler.FestivalHandler, ScreeningsHandler *handler.ScreeningsHandler, TicketsHandler *handler.TicketsHandler, JuryHandler *handler.JuryHandler, AwardsHandler *handler.AwardsHandler, SubmissionsHandler *handler.SubmissionsHandler, BallotsHandler *handler.BallotsHandler) *gin.Engine {
+	// Log requests with sensitive query parameters masked instead of gin's default logger
+	r := gin.New()
+	r.Use(middleware.RequestLogger(), gin.Recovery())
+
+	// Public keys for services verifying our access tokens
+	r.GET("/.well-known/jwks.json", handler.GetJWKS)
 
 	// Group routes
 	api := r.Group("/api/v1")
 	{
-		UserRoutes(api, UserHandler)
-		MovieRoutes(api, MoviesHandler)
+		UserRoutes(api, UserHandler, OIDCHandler)
+		MovieRoutes(api, MoviesHandler, AvailabilityHandler)
+		SubmissionRoutes(api, SubmissionsHandler)
+		FestivalRoutes(api, FestivalHandler)
+		ScreeningRoutes(api, ScreeningsHandler)
+		TicketRoutes(api, TicketsHandler)
+		JuryRoutes(api, JuryHandler)
+		AwardRoutes(api, AwardsHandler)
+		BallotRoutes(api, BallotsHandler)
 		StatsRoutes(api, StatsHandler)
+		AdminRoutes(api, UserHandler, AbuseHandler)
+		RecommendationRoutes(api, RecommendationsHandler)
 	}
 
 	return r
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"movies/model"
+	"movies/repository"
+	"movies/utils"
+	"strings"
+)
+
+// Weights of the individual abuse rules; an event is flagged once its score reaches abuseFlagThreshold.
+const (
+	abuseScoreFlaggedAccount = 100 // Event by an account that is already flagged
+	abuseScoreUserViewRate   = 60  // Too many views by one user in a minute
+	abuseScoreIPViewRate     = 60  // Too many views from one IP address in a minute
+	abuseScoreNoWatchTime    = 60  // Vote without enough watch time
+	abuseScoreRegistrations  = 100 // Too many registrations from one IP address
+	abuseFlagThreshold       = 50
+)
+
+type AbuseUseCase struct {
+	AbuseRepo *repository.AbuseRepo
+}
+
+func NewAbuseUseCase(AbuseRepo *repository.AbuseRepo) *AbuseUseCase {
+	return &AbuseUseCase{AbuseRepo: AbuseRepo}
+}
+
+// abuseScore accumulates the score and reasons of the rules an event triggered.
+type abuseScore struct {
+	score   int
+	reasons []string
+}
+
+func (s *abuseScore) add(points int, reason string) {
+	s.score += points
+	s.reasons = append(s.reasons, reason)
+}
+
+// ScoreView checks a freshly saved playback session against the view rate rules.
+// Scoring problems are logged and never block the view itself.
+func (uc *AbuseUseCase) ScoreView(view *model.MovieView) {
+	var result abuseScore
+
+	if view.UserID != 0 {
+		flagged, err := uc.AbuseRepo.IsUserFlagged(view.UserID)
+		if err != nil {
+			log.Println("ERR score view: ", err)
+			return
+		}
+		if flagged {
+			result.add(abuseScoreFlaggedAccount, "account is flagged")
+		}
+
+		maxViews := utils.GetEnvInt("ABUSE_MAX_VIEWS_PER_MINUTE", 10)
+		count, err := uc.AbuseRepo.CountRecentViewsByUser(view.UserID, 60)
+		if err != nil {
+			log.Println("ERR score view: ", err)
+			return
+		}
+		if count > maxViews {
+			result.add(abuseScoreUserViewRate, fmt.Sprintf("%d views by user in the last minute", count))
+		}
+	}
+
+	if view.IPAddress != "" {
+		maxViews := utils.GetEnvInt("ABUSE_MAX_IP_VIEWS_PER_MINUTE", 30)
+		count, err := uc.AbuseRepo.CountRecentViewsByIP(view.IPAddress, 60)
+		if err != nil {
+			log.Println("ERR score view: ", err)
+			return
+		}
+		if count > maxViews {
+			result.add(abuseScoreIPViewRate, fmt.Sprintf("%d views from ip in the last minute", count))
+		}
+	}
+
+	uc.flagIfSuspicious("view", view.ID, view.UserID, view.IPAddress, result)
+}
+
+// ScoreVote checks a freshly recorded like against the watch time rule.
+// Scoring problems are logged and never block the vote itself.
+func (uc *AbuseUseCase) ScoreVote(userID, movieID int, ip string) {
+	var result abuseScore
+
+	voteID, err := uc.AbuseRepo.GetVoteID(userID, movieID)
+	if err != nil {
+		log.Println("ERR score vote: ", err)
+		return
+	}
+
+	flagged, err := uc.AbuseRepo.IsUserFlagged(userID)
+	if err != nil {
+		log.Println("ERR score vote: ", err)
+		return
+	}
+	if flagged {
+		result.add(abuseScoreFlaggedAccount, "account is flagged")
+	}
+
+	minWatch := utils.GetEnvInt("ABUSE_MIN_WATCH_SECONDS", 60)
+	watched, err := uc.AbuseRepo.GetWatchedSeconds(userID, movieID)
+	if err != nil {
+		log.Println("ERR score vote: ", err)
+		return
+	}
+	if watched < minWatch {
+		result.add(abuseScoreNoWatchTime, fmt.Sprintf("voted after watching %d seconds", watched))
+	}
+
+	uc.flagIfSuspicious("vote", voteID, userID, ip, result)
+}
+
+// ScoreRegistration checks a new account against the registration burst rule.
+// Scoring problems are logged and never block the registration itself.
+func (uc *AbuseUseCase) ScoreRegistration(user *model.Users) {
+	if user.RegistrationIP == "" {
+		return
+	}
+
+	var result abuseScore
+
+	maxRegistrations := utils.GetEnvInt("ABUSE_MAX_REGISTRATIONS_PER_HOUR", 5)
+	count, err := uc.AbuseRepo.CountRecentRegistrationsByIP(user.RegistrationIP, 60)
+	if err != nil {
+		log.Println("ERR score registration: ", err)
+		return
+	}
+	if count > maxRegistrations {
+		result.add(abuseScoreRegistrations, fmt.Sprintf("%d registrations from ip in the last hour", count))
+	}
+
+	uc.flagIfSuspicious("registration", user.Id, user.Id, user.RegistrationIP, result)
+}
+
+// flagIfSuspicious records a flag and excludes the event from stats when its score reaches the threshold.
+func (uc *AbuseUseCase) flagIfSuspicious(eventType string, eventID, userID int, ip string, result abuseScore) {
+	if result.score < abuseFlagThreshold {
+		return
+	}
+
+	flag := &model.AbuseFlag{
+		EventType: eventType,
+		EventID:   eventID,
+		IPAddress: ip,
+		Score:     result.score,
+		Reason:    strings.Join(result.reasons, "; "),
+	}
+	if userID != 0 {
+		flag.UserID = &userID
+	}
+
+	if err := uc.AbuseRepo.SetEventFlagged(eventType, eventID, true); err != nil {
+		log.Println("ERR flag event: ", err)
+		return
+	}
+	if err := uc.AbuseRepo.CreateFlag(flag); err != nil {
+		log.Println("ERR flag event: ", err)
+	}
+}
+
+// ListFlags retrieves abuse flags for admin review.
+func (uc *AbuseUseCase) ListFlags(status string) ([]model.AbuseFlag, error) {
+	if status != "" && status != "open" && status != "confirmed" && status != "dismissed" {
+		return nil, fmt.Errorf("invalid status")
+	}
+
+	return uc.AbuseRepo.ListFlags(status)
+}
+
+// ReviewFlag confirms or dismisses an abuse flag.
+// Dismissing a flag clears the flagged marker so the event counts in stats again.
+func (uc *AbuseUseCase) ReviewFlag(flagID, reviewerID int, status string) error {
+	if status != "confirmed" && status != "dismissed" {
+		return fmt.Errorf("invalid status")
+	}
+
+	flag, err := uc.AbuseRepo.GetFlag(flagID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("flag not found")
+		}
+		return fmt.Errorf("failed to load flag: %w", err)
+	}
+
+	if err := uc.AbuseRepo.SetEventFlagged(flag.EventType, flag.EventID, status == "confirmed"); err != nil {
+		return err
+	}
+
+	return uc.AbuseRepo.ReviewFlag(flagID, status, reviewerID)
+}
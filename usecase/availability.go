@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"movies/geo"
+	"movies/model"
+	"movies/repository"
+	"sort"
+	"strings"
+	"time"
+)
+
+type AvailabilityUseCase struct {
+	AvailabilityRepo *repository.AvailabilityRepo
+	MoviesRepo       *repository.MoviesRepo
+	RolesRepo        *repository.RolesRepo
+	CountryResolver  geo.CountryResolver
+}
+
+func NewAvailabilityUseCase(AvailabilityRepo *repository.AvailabilityRepo, MoviesRepo *repository.MoviesRepo, RolesRepo *repository.RolesRepo, CountryResolver geo.CountryResolver) *AvailabilityUseCase {
+	return &AvailabilityUseCase{AvailabilityRepo: AvailabilityRepo, MoviesRepo: MoviesRepo, RolesRepo: RolesRepo, CountryResolver: CountryResolver}
+}
+
+// GetAvailability returns the availability rules of a movie. Movies without rules get an empty rule
+// set, available everywhere at any time.
+func (au *AvailabilityUseCase) GetAvailability(movieID int) (*model.MovieAvailability, error) {
+	if err := au.checkMovie(movieID); err != nil {
+		return nil, err
+	}
+
+	availability, err := au.AvailabilityRepo.GetAvailability(movieID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &model.MovieAvailability{MovieID: movieID, Countries: []string{}}, nil
+	}
+
+	return availability, err
+}
+
+// SaveAvailability validates and replaces the availability rules of a movie. Country codes are
+// upper-cased and deduplicated.
+func (au *AvailabilityUseCase) SaveAvailability(movieID int, request *model.RequestMovieAvailability) (*model.MovieAvailability, error) {
+	if request.AvailableFrom != nil && request.AvailableUntil != nil && !request.AvailableUntil.After(*request.AvailableFrom) {
+		return nil, fmt.Errorf("invalid availability window")
+	}
+	if request.MaxViewers != nil && *request.MaxViewers < 1 {
+		return nil, fmt.Errorf("invalid max viewers")
+	}
+
+	countries := []string{}
+	seen := map[string]bool{}
+	for _, country := range request.Countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+			return nil, fmt.Errorf("invalid country")
+		}
+		if !seen[country] {
+			seen[country] = true
+			countries = append(countries, country)
+		}
+	}
+	if len(countries) > 300 {
+		return nil, fmt.Errorf("invalid country")
+	}
+	sort.Strings(countries)
+
+	if err := au.checkMovie(movieID); err != nil {
+		return nil, err
+	}
+
+	availability := &model.MovieAvailability{
+		MovieID:        movieID,
+		AvailableFrom:  request.AvailableFrom,
+		AvailableUntil: request.AvailableUntil,
+		Countries:      countries,
+		MaxViewers:     request.MaxViewers,
+	}
+	if err := au.AvailabilityRepo.SaveAvailability(availability); err != nil {
+		return nil, err
+	}
+
+	return au.AvailabilityRepo.GetAvailability(movieID)
+}
+
+// DeleteAvailability removes the availability rules of a movie, making it available everywhere.
+func (au *AvailabilityUseCase) DeleteAvailability(movieID int) error {
+	if err := au.checkMovie(movieID); err != nil {
+		return err
+	}
+
+	deleted, err := au.AvailabilityRepo.DeleteAvailability(movieID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("availability not found")
+	}
+
+	return nil
+}
+
+// CheckPlayback checks that a movie can be watched now from an IP address: inside its availability
+// window and from an allowed country. It returns the movie's rules, nil when it has none, so callers
+// can enforce max_viewers when starting a session.
+func (au *AvailabilityUseCase) CheckPlayback(movieID int, ip string) (*model.MovieAvailability, error) {
+	availability, err := au.AvailabilityRepo.GetAvailability(movieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if (availability.AvailableFrom != nil && now.Before(*availability.AvailableFrom)) ||
+		(availability.AvailableUntil != nil && !now.Before(*availability.AvailableUntil)) {
+		return nil, fmt.Errorf("movie outside availability window")
+	}
+
+	if len(availability.Countries) > 0 {
+		country := au.ResolveCountry(ip)
+		allowed := false
+		for _, code := range availability.Countries {
+			if code == country {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("movie not available in country")
+		}
+	}
+
+	return availability, nil
+}
+
+// ListingFilter returns the country public movie lists are filtered for, or nil to list every movie.
+// Only roles with movies:write can include unavailable movies.
+func (au *AvailabilityUseCase) ListingFilter(role, ip string, includeUnavailable bool) (*string, error) {
+	if includeUnavailable {
+		allowed := false
+		if role != "" {
+			var err error
+			if allowed, err = au.RolesRepo.RoleHasPermission(role, "movies:write"); err != nil {
+				return nil, err
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("unavailable movies forbidden")
+		}
+		return nil, nil
+	}
+
+	country := au.ResolveCountry(ip)
+	return &country, nil
+}
+
+// ResolveCountry returns the country code of an IP address, or "" when it is unknown. Resolver
+// failures are logged and treated as an unknown country.
+func (au *AvailabilityUseCase) ResolveCountry(ip string) string {
+	country, err := au.CountryResolver.Country(ip)
+	if err != nil {
+		log.Println("ERR resolve country: ", err)
+		return ""
+	}
+
+	return country
+}
+
+// checkMovie reports "movie not found" for unknown movies.
+func (au *AvailabilityUseCase) checkMovie(movieID int) error {
+	exists, err := au.MoviesRepo.MovieExists(movieID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("movie not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"movies/repository"
+	"strings"
+)
+
+type AwardsUseCase struct {
+	AwardsRepo      *repository.AwardsRepo
+	MoviesRepo      *repository.MoviesRepo
+	BallotsUseCase  *BallotsUseCase
+	FestivalUseCase *FestivalUseCase
+}
+
+func NewAwardsUseCase(AwardsRepo *repository.AwardsRepo, MoviesRepo *repository.MoviesRepo, BallotsUseCase *BallotsUseCase, FestivalUseCase *FestivalUseCase) *AwardsUseCase {
+	return &AwardsUseCase{AwardsRepo: AwardsRepo, MoviesRepo: MoviesRepo, BallotsUseCase: BallotsUseCase, FestivalUseCase: FestivalUseCase}
+}
+
+// ListPublishedAwards returns the awards of an edition as the public sees them: nominations are
+// listed, but winners and the announcement time stay hidden until the announcement time has passed.
+// The edition is resolved like the statistics filter: empty for the current edition, "all" for every edition.
+func (au *AwardsUseCase) ListPublishedAwards(edition string) (*int, []model.AwardCategory, error) {
+	editionID, categories, err := au.ListCategories(edition)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range categories {
+		if categories[i].Published {
+			continue
+		}
+		categories[i].PublishedAt = nil
+		for j := range categories[i].Nominations {
+			categories[i].Nominations[j].IsWinner = false
+		}
+	}
+
+	return editionID, categories, nil
+}
+
+// ListCategories returns the awards of an edition including unpublished winners.
+func (au *AwardsUseCase) ListCategories(edition string) (*int, []model.AwardCategory, error) {
+	editionID, err := au.FestivalUseCase.ResolveEdition(edition)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	categories, err := au.AwardsRepo.ListCategories(editionFilter(editionID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return editionID, categories, nil
+}
+
+// GetCategory returns an award with its nominations.
+func (au *AwardsUseCase) GetCategory(categoryID int) (*model.AwardCategory, error) {
+	category, err := au.AwardsRepo.GetCategory(categoryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("award not found")
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// CreateCategory validates and stores a new award of an edition.
+func (au *AwardsUseCase) CreateCategory(request *model.RequestAwardCategory) (*model.AwardCategory, error) {
+	if _, err := au.FestivalUseCase.GetEdition(request.EditionID); err != nil {
+		return nil, err
+	}
+
+	category := &model.AwardCategory{EditionID: request.EditionID}
+	if err := au.validateCategory(category, request); err != nil {
+		return nil, err
+	}
+
+	if err := au.AwardsRepo.CreateCategory(category); err != nil {
+		return nil, err
+	}
+
+	return au.GetCategory(category.ID)
+}
+
+// UpdateCategory replaces the name, description and kind of an unpublished award. Its edition cannot change.
+func (au *AwardsUseCase) UpdateCategory(categoryID int, request *model.RequestAwardCategory) (*model.AwardCategory, error) {
+	category, err := au.getOpenCategory(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := au.validateCategory(category, request); err != nil {
+		return nil, err
+	}
+
+	if err := au.AwardsRepo.UpdateCategory(category); err != nil {
+		return nil, err
+	}
+
+	return au.GetCategory(categoryID)
+}
+
+// DeleteCategory deletes an unpublished award with its nominations.
+func (au *AwardsUseCase) DeleteCategory(categoryID int) error {
+	if _, err := au.getOpenCategory(categoryID); err != nil {
+		return err
+	}
+
+	return au.AwardsRepo.DeleteCategory(categoryID)
+}
+
+// SaveNomination nominates a movie of the award's edition for a jury award.
+func (au *AwardsUseCase) SaveNomination(categoryID int, request *model.RequestNomination) (*model.AwardCategory, error) {
+	category, err := au.getOpenCategory(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category.Kind == model.AwardKindAudience {
+		return nil, fmt.Errorf("audience award is computed")
+	}
+
+	citation := strings.TrimSpace(request.Citation)
+	if len(citation) > 255 {
+		return nil, fmt.Errorf("citation too long")
+	}
+
+	editionID, err := au.MoviesRepo.GetMovieEdition(request.MovieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("movie not found")
+		}
+		return nil, err
+	}
+	if editionID == nil || *editionID != category.EditionID {
+		return nil, fmt.Errorf("movie not in edition")
+	}
+
+	if err := au.AwardsRepo.SaveNomination(categoryID, request.MovieID, citation); err != nil {
+		return nil, err
+	}
+
+	return au.GetCategory(categoryID)
+}
+
+// DeleteNomination withdraws a nomination from an unpublished award.
+func (au *AwardsUseCase) DeleteNomination(categoryID, movieID int) error {
+	if _, err := au.getOpenCategory(categoryID); err != nil {
+		return err
+	}
+
+	deleted, err := au.AwardsRepo.DeleteNomination(categoryID, movieID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("nomination not found")
+	}
+
+	return nil
+}
+
+// SetWinners chooses the winners of a jury award among its nominees. Several winners make an
+// ex aequo award; an empty list clears the winners.
+func (au *AwardsUseCase) SetWinners(categoryID int, request *model.RequestAwardWinners) (*model.AwardCategory, error) {
+	category, err := au.getOpenCategory(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category.Kind == model.AwardKindAudience {
+		return nil, fmt.Errorf("audience award is computed")
+	}
+
+	nominated := make(map[int]bool, len(category.Nominations))
+	for _, nomination := range category.Nominations {
+		nominated[nomination.MovieID] = true
+	}
+	for _, movieID := range request.MovieIDs {
+		if !nominated[movieID] {
+			return nil, fmt.Errorf("movie not nominated")
+		}
+	}
+
+	if err := au.AwardsRepo.SetWinners(categoryID, request.MovieIDs); err != nil {
+		return nil, err
+	}
+
+	return au.GetCategory(categoryID)
+}
+
+// ComputeAudienceAward awards an audience award to the movies of its edition with the best average
+// ballot score. Only movies eligible in the ballot tally qualify; tied movies win ex aequo.
+// Earlier results of the award are replaced.
+func (au *AwardsUseCase) ComputeAudienceAward(categoryID int) (*model.AwardCategory, error) {
+	category, err := au.getOpenCategory(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category.Kind != model.AwardKindAudience {
+		return nil, fmt.Errorf("not an audience award")
+	}
+
+	tally, err := au.BallotsUseCase.GetTally(category.EditionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tally is sorted by average score, so the first eligible movie sets the winning score
+	var winners []model.AwardNomination
+	var winningScore float64
+	for _, movie := range tally.Movies {
+		if !movie.Eligible {
+			continue
+		}
+		if len(winners) == 0 {
+			winningScore = movie.AverageScore
+		} else if movie.AverageScore < winningScore {
+			break
+		}
+		winners = append(winners, model.AwardNomination{
+			MovieID:  movie.MovieID,
+			Citation: fmt.Sprintf("%.2f average score from %d audience ballots", movie.AverageScore, movie.Ballots),
+		})
+	}
+	if len(winners) == 0 {
+		return nil, fmt.Errorf("no eligible movies")
+	}
+
+	if err := au.AwardsRepo.ReplaceWinners(categoryID, winners); err != nil {
+		return nil, err
+	}
+
+	return au.GetCategory(categoryID)
+}
+
+// PublishCategory schedules the announcement of an award's winners, immediately when no time is given.
+// The announcement can be rescheduled until it has passed.
+func (au *AwardsUseCase) PublishCategory(categoryID int, request *model.RequestPublishAward) (*model.AwardCategory, error) {
+	category, err := au.getOpenCategory(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasWinner := false
+	for _, nomination := range category.Nominations {
+		if nomination.IsWinner {
+			hasWinner = true
+			break
+		}
+	}
+	if !hasWinner {
+		return nil, fmt.Errorf("award has no winner")
+	}
+
+	if err := au.AwardsRepo.PublishCategory(categoryID, request.PublishedAt); err != nil {
+		return nil, err
+	}
+
+	return au.GetCategory(categoryID)
+}
+
+// validateCategory checks an award payload and copies it into the category.
+func (au *AwardsUseCase) validateCategory(category *model.AwardCategory, request *model.RequestAwardCategory) error {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || len(name) > 100 {
+		return fmt.Errorf("invalid award name")
+	}
+
+	kind := request.Kind
+	if kind == "" {
+		kind = model.AwardKindJury
+	}
+	if kind != model.AwardKindJury && kind != model.AwardKindAudience {
+		return fmt.Errorf("invalid award kind")
+	}
+
+	taken, err := au.AwardsRepo.CategoryNameTaken(category.EditionID, name, category.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("award name already exists")
+	}
+
+	category.Name = name
+	category.Description = strings.TrimSpace(request.Description)
+	category.Kind = kind
+	return nil
+}
+
+// getOpenCategory loads an award whose winners are not public yet. Published awards are final.
+func (au *AwardsUseCase) getOpenCategory(categoryID int) (*model.AwardCategory, error) {
+	category, err := au.GetCategory(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category.Published {
+		return nil, fmt.Errorf("award published")
+	}
+
+	return category, nil
+}
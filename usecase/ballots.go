@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"movies/model"
+	"movies/repository"
+	"movies/utils"
+)
+
+type BallotsUseCase struct {
+	BallotsRepo  *repository.BallotsRepo
+	MoviesRepo   *repository.MoviesRepo
+	FestivalRepo *repository.FestivalRepo
+}
+
+func NewBallotsUseCase(BallotsRepo *repository.BallotsRepo, MoviesRepo *repository.MoviesRepo, FestivalRepo *repository.FestivalRepo) *BallotsUseCase {
+	return &BallotsUseCase{BallotsRepo: BallotsRepo, MoviesRepo: MoviesRepo, FestivalRepo: FestivalRepo}
+}
+
+// CastBallot stores a user's audience-award ballot for a movie with a score from 1 to 5. Only
+// attendees vote: the user needs a checked-in ticket for a screening of the movie, or online sessions
+// covering AUDIENCE_BALLOT_MIN_WATCH_PERCENT (default 90) of its running time. Ballots are final.
+func (bu *BallotsUseCase) CastBallot(userID, movieID int, request *model.RequestBallot) (*model.Ballot, error) {
+	if request.Score < 1 || request.Score > 5 {
+		return nil, fmt.Errorf("invalid score")
+	}
+
+	movie, err := bu.MoviesRepo.GetMovie(movieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("movie not found")
+		}
+		return nil, err
+	}
+
+	ballot := &model.Ballot{MovieID: movie.ID, Title: movie.Title, Score: request.Score}
+
+	var ticketID *int
+	attendedTicket, err := bu.BallotsRepo.GetAttendedTicket(userID, movieID)
+	switch {
+	case err == nil:
+		ballot.Source = model.BallotSourceScreening
+		ticketID = &attendedTicket
+	case errors.Is(err, sql.ErrNoRows):
+		watchedFully, err := bu.watchedFully(userID, movie)
+		if err != nil {
+			return nil, err
+		}
+		if !watchedFully {
+			return nil, fmt.Errorf("not an attendee")
+		}
+		ballot.Source = model.BallotSourceOnline
+	default:
+		return nil, err
+	}
+
+	created, err := bu.BallotsRepo.Create(userID, ballot, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, fmt.Errorf("ballot already cast")
+	}
+
+	return ballot, nil
+}
+
+// ListUserBallots returns the ballots a user has cast.
+func (bu *BallotsUseCase) ListUserBallots(userID int) ([]model.Ballot, error) {
+	return bu.BallotsRepo.ListUserBallots(userID)
+}
+
+// GetTally returns the audience-award tally of an edition, the current one when editionID is zero.
+// Movies need AUDIENCE_AWARD_MIN_BALLOTS (default 20) ballots to be eligible for the award.
+func (bu *BallotsUseCase) GetTally(editionID int) (*model.BallotTally, error) {
+	var edition *model.FestivalEdition
+	var err error
+	if editionID == 0 {
+		edition, err = bu.FestivalRepo.GetCurrentEdition()
+	} else {
+		edition, err = bu.FestivalRepo.GetEdition(editionID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("edition not found")
+		}
+		return nil, err
+	}
+
+	movies, err := bu.BallotsRepo.GetTally(edition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	minBallots := utils.GetEnvInt("AUDIENCE_AWARD_MIN_BALLOTS", 20)
+	for i := range movies {
+		movies[i].AverageScore = math.Round(movies[i].AverageScore*100) / 100
+		movies[i].Eligible = movies[i].Ballots >= minBallots
+	}
+
+	return &model.BallotTally{EditionID: edition.ID, MinBallots: minBallots, Movies: movies}, nil
+}
+
+// watchedFully checks whether one of a user's online sessions covers enough of a movie's running time
+// to count as a full watch; partial sessions do not add up. Movies with an unreadable duration cannot
+// be verified.
+func (bu *BallotsUseCase) watchedFully(userID int, movie *model.Movies) (bool, error) {
+	runtime, err := utils.ParseMovieDuration(movie.Duration)
+	if err != nil {
+		return false, nil
+	}
+	runtimeSeconds := int(runtime.Seconds())
+
+	watched, err := bu.BallotsRepo.GetLongestOnlineSession(userID, movie.ID, runtimeSeconds)
+	if err != nil {
+		return false, err
+	}
+
+	minPercent := utils.GetEnvInt("AUDIENCE_BALLOT_MIN_WATCH_PERCENT", 90)
+	return watched*100 >= runtimeSeconds*minPercent, nil
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"movies/utils"
+	"sync"
+	"time"
+)
+
+// AlreadyCheckedInError is returned when a ticket is scanned again.
+type AlreadyCheckedInError struct {
+	CheckedInAt time.Time // Time of the original scan
+}
+
+func (e *AlreadyCheckedInError) Error() string {
+	return "ticket already checked in"
+}
+
+// AttendanceHub notifies live attendance subscribers of check-ins at a screening.
+// Notifications only reach subscribers of the same server process; the stream also refreshes periodically.
+type AttendanceHub struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+}
+
+func NewAttendanceHub() *AttendanceHub {
+	return &AttendanceHub{subscribers: make(map[int]map[chan struct{}]struct{})}
+}
+
+// Subscribe registers for check-in notifications of a screening. The returned function unsubscribes.
+func (h *AttendanceHub) Subscribe(screeningID int) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.subscribers[screeningID] == nil {
+		h.subscribers[screeningID] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[screeningID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subscribers[screeningID], ch)
+		if len(h.subscribers[screeningID]) == 0 {
+			delete(h.subscribers, screeningID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// notify wakes the subscribers of a screening without blocking on slow ones.
+func (h *AttendanceHub) notify(screeningID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[screeningID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// A notification is already pending
+		}
+	}
+}
+
+// CheckIn admits the holder of a signed ticket code. A ticket is admitted once; scanning it again returns
+// an AlreadyCheckedInError with the original scan time. Doors open CHECKIN_OPENS_MINUTES (default 60)
+// before the screening and close when it ends.
+func (tu *TicketsUseCase) CheckIn(staffID int, request *model.RequestCheckIn) (*model.CheckIn, error) {
+	ticketID, code, ok := VerifyTicketCode(request.Code)
+	if !ok {
+		return nil, fmt.Errorf("invalid ticket code")
+	}
+
+	ticket, err := tu.TicketsRepo.GetTicket(ticketID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invalid ticket code")
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(ticket.Code), []byte(code)) != 1 {
+		return nil, fmt.Errorf("invalid ticket code")
+	}
+
+	if ticket.CheckedInAt != nil {
+		return nil, &AlreadyCheckedInError{CheckedInAt: *ticket.CheckedInAt}
+	}
+	if ticket.Status != model.TicketStatusConfirmed {
+		return nil, fmt.Errorf("ticket not valid")
+	}
+	if request.ScreeningID != 0 && request.ScreeningID != ticket.ScreeningID {
+		return nil, fmt.Errorf("wrong screening")
+	}
+
+	screening, err := tu.ScreeningsRepo.GetScreening(ticket.ScreeningID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	opensAt := screening.StartsAt.Add(-time.Duration(utils.GetEnvInt("CHECKIN_OPENS_MINUTES", 60)) * time.Minute)
+	if now.Before(opensAt) || !now.Before(screening.EndsAt) {
+		return nil, fmt.Errorf("check-in closed")
+	}
+
+	// Every seat becomes one screening view
+	sessionIDs := make([]string, 0, ticket.Seats)
+	for i := 0; i < ticket.Seats; i++ {
+		sessionID, err := utils.GenerateToken(16)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate session id: %w", err)
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+
+	checkedInAt, first, err := tu.TicketsRepo.CheckIn(ticket, staffID, screening, sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, &AlreadyCheckedInError{CheckedInAt: checkedInAt}
+	}
+
+	tu.AttendanceHub.notify(ticket.ScreeningID)
+
+	return &model.CheckIn{
+		TicketID:    ticket.ID,
+		ScreeningID: ticket.ScreeningID,
+		Seats:       ticket.Seats,
+		MovieTitle:  screening.MovieTitle,
+		StartsAt:    screening.StartsAt,
+		CheckedInAt: checkedInAt,
+	}, nil
+}
+
+// GetAttendance returns the sold and checked-in seats of a screening.
+func (tu *TicketsUseCase) GetAttendance(screeningID int) (*model.Attendance, error) {
+	attendance, err := tu.TicketsRepo.GetAttendance(screeningID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("screening not found")
+		}
+		return nil, err
+	}
+
+	return attendance, nil
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"movies/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FestivalUseCase struct {
+	FestivalRepo *repository.FestivalRepo
+}
+
+func NewFestivalUseCase(FestivalRepo *repository.FestivalRepo) *FestivalUseCase {
+	return &FestivalUseCase{FestivalRepo: FestivalRepo}
+}
+
+// ListEditions returns all festival editions, newest first.
+func (fu *FestivalUseCase) ListEditions() ([]model.FestivalEdition, error) {
+	return fu.FestivalRepo.ListEditions()
+}
+
+// GetEdition returns an edition together with its programme sections.
+func (fu *FestivalUseCase) GetEdition(editionID int) (*model.FestivalEdition, error) {
+	edition, err := fu.getEdition(editionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := fu.FestivalRepo.ListSections(editionID)
+	if err != nil {
+		return nil, err
+	}
+	edition.Sections = sections
+
+	return edition, nil
+}
+
+// CreateEdition validates and stores a new festival edition.
+func (fu *FestivalUseCase) CreateEdition(request *model.RequestEdition) (*model.FestivalEdition, error) {
+	edition, err := fu.validateEdition(0, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fu.FestivalRepo.CreateEdition(edition); err != nil {
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+// UpdateEdition validates and replaces the year, theme, dates and review mode of an edition.
+func (fu *FestivalUseCase) UpdateEdition(editionID int, request *model.RequestEdition) (*model.FestivalEdition, error) {
+	if _, err := fu.getEdition(editionID); err != nil {
+		return nil, err
+	}
+
+	edition, err := fu.validateEdition(editionID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fu.FestivalRepo.UpdateEdition(edition); err != nil {
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+// DeleteEdition deletes an edition and its sections. Editions that still have movies are kept,
+// because deleting them would merge their statistics into the unassigned catalogue.
+func (fu *FestivalUseCase) DeleteEdition(editionID int) error {
+	if _, err := fu.getEdition(editionID); err != nil {
+		return err
+	}
+
+	count, err := fu.FestivalRepo.CountEditionMovies(editionID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("edition has movies")
+	}
+
+	return fu.FestivalRepo.DeleteEdition(editionID)
+}
+
+// CreateSection adds a programme section to an edition.
+func (fu *FestivalUseCase) CreateSection(editionID int, request *model.RequestSection) (*model.ProgrammeSection, error) {
+	if _, err := fu.getEdition(editionID); err != nil {
+		return nil, err
+	}
+
+	section := &model.ProgrammeSection{
+		EditionID:   editionID,
+		Name:        strings.TrimSpace(request.Name),
+		Description: strings.TrimSpace(request.Description),
+	}
+	if err := fu.validateSection(section); err != nil {
+		return nil, err
+	}
+
+	if err := fu.FestivalRepo.CreateSection(section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// UpdateSection renames or redescribes a programme section of an edition.
+func (fu *FestivalUseCase) UpdateSection(editionID, sectionID int, request *model.RequestSection) (*model.ProgrammeSection, error) {
+	section, err := fu.getSection(editionID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	section.Name = strings.TrimSpace(request.Name)
+	section.Description = strings.TrimSpace(request.Description)
+	if err := fu.validateSection(section); err != nil {
+		return nil, err
+	}
+
+	if err := fu.FestivalRepo.UpdateSection(section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// DeleteSection deletes a programme section of an edition; its movies stay in the edition.
+// Sections with a jury panel are kept until the panel is deleted.
+func (fu *FestivalUseCase) DeleteSection(editionID, sectionID int) error {
+	if _, err := fu.getSection(editionID, sectionID); err != nil {
+		return err
+	}
+
+	hasJury, err := fu.FestivalRepo.SectionHasJury(sectionID)
+	if err != nil {
+		return err
+	}
+	if hasJury {
+		return fmt.Errorf("section has jury")
+	}
+
+	return fu.FestivalRepo.DeleteSection(sectionID)
+}
+
+// ResolveEdition turns the edition_id query parameter of the statistics endpoints into an edition ID.
+// An empty value selects the current edition, "all" disables the filter, and nil is returned when
+// statistics should cover every edition.
+func (fu *FestivalUseCase) ResolveEdition(value string) (*int, error) {
+	switch value {
+	case "all":
+		return nil, nil
+	case "":
+		edition, err := fu.FestivalRepo.GetCurrentEdition()
+		if err != nil {
+			// Without any edition the whole catalogue is the current festival
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &edition.ID, nil
+	}
+
+	editionID, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid edition")
+	}
+	if _, err := fu.getEdition(editionID); err != nil {
+		return nil, err
+	}
+
+	return &editionID, nil
+}
+
+// validateEdition checks an edition payload and returns the edition to store.
+func (fu *FestivalUseCase) validateEdition(editionID int, request *model.RequestEdition) (*model.FestivalEdition, error) {
+	if request.Year < 1900 || request.Year > 9999 {
+		return nil, fmt.Errorf("invalid year")
+	}
+
+	startsOn, err := time.Parse("2006-01-02", request.StartsOn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dates")
+	}
+	endsOn, err := time.Parse("2006-01-02", request.EndsOn)
+	if err != nil || endsOn.Before(startsOn) {
+		return nil, fmt.Errorf("invalid dates")
+	}
+
+	theme := strings.TrimSpace(request.Theme)
+	if len(theme) > 255 {
+		return nil, fmt.Errorf("theme too long")
+	}
+
+	taken, err := fu.FestivalRepo.YearTaken(request.Year, editionID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("edition year already exists")
+	}
+
+	return &model.FestivalEdition{
+		ID:          editionID,
+		Year:        request.Year,
+		Theme:       theme,
+		StartsOn:    request.StartsOn,
+		EndsOn:      request.EndsOn,
+		BlindReview: request.BlindReview,
+	}, nil
+}
+
+// validateSection checks the name of a section and that it is unique within its edition.
+func (fu *FestivalUseCase) validateSection(section *model.ProgrammeSection) error {
+	if section.Name == "" || len(section.Name) > 100 {
+		return fmt.Errorf("invalid section name")
+	}
+
+	taken, err := fu.FestivalRepo.SectionNameTaken(section.EditionID, section.Name, section.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("section name already exists")
+	}
+
+	return nil
+}
+
+// getEdition loads an edition, mapping a missing row to "edition not found".
+func (fu *FestivalUseCase) getEdition(editionID int) (*model.FestivalEdition, error) {
+	edition, err := fu.FestivalRepo.GetEdition(editionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("edition not found")
+		}
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+// getSection loads a section of an edition, reporting sections of other editions as missing.
+func (fu *FestivalUseCase) getSection(editionID, sectionID int) (*model.ProgrammeSection, error) {
+	section, err := fu.FestivalRepo.GetSection(sectionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("section not found")
+		}
+		return nil, err
+	}
+	if section.EditionID != editionID {
+		return nil, fmt.Errorf("section not found")
+	}
+
+	return section, nil
+}
@@ -0,0 +1,443 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"movies/model"
+	"movies/repository"
+	"strings"
+)
+
+// JuryIncompleteError is returned when a panel is locked before all scores are in.
+type JuryIncompleteError struct {
+	Missing int // Scores still missing
+}
+
+func (e *JuryIncompleteError) Error() string {
+	return "scores incomplete"
+}
+
+type JuryUseCase struct {
+	JuryRepo     *repository.JuryRepo
+	FestivalRepo *repository.FestivalRepo
+	UsersRepo    *repository.UsersRepo
+	RolesRepo    *repository.RolesRepo
+}
+
+func NewJuryUseCase(JuryRepo *repository.JuryRepo, FestivalRepo *repository.FestivalRepo, UsersRepo *repository.UsersRepo, RolesRepo *repository.RolesRepo) *JuryUseCase {
+	return &JuryUseCase{JuryRepo: JuryRepo, FestivalRepo: FestivalRepo, UsersRepo: UsersRepo, RolesRepo: RolesRepo}
+}
+
+// ListPanels returns every jury panel.
+func (ju *JuryUseCase) ListPanels() ([]model.JuryPanel, error) {
+	return ju.JuryRepo.ListPanels()
+}
+
+// ListUserPanels returns the panels a juror sits on.
+func (ju *JuryUseCase) ListUserPanels(userID int) ([]model.JuryPanel, error) {
+	return ju.JuryRepo.ListUserPanels(userID)
+}
+
+// CreatePanel sets up the jury of a programme section. A section has at most one jury.
+func (ju *JuryUseCase) CreatePanel(request *model.RequestJuryPanel) (*model.JuryPanel, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("invalid panel name")
+	}
+
+	if _, err := ju.FestivalRepo.GetSection(request.SectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("section not found")
+		}
+		return nil, err
+	}
+
+	hasJury, err := ju.FestivalRepo.SectionHasJury(request.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	if hasJury {
+		return nil, fmt.Errorf("section already has a jury")
+	}
+
+	panel := &model.JuryPanel{SectionID: request.SectionID, Name: name}
+	if err := ju.JuryRepo.CreatePanel(panel); err != nil {
+		return nil, err
+	}
+
+	return ju.getPanel(panel.ID)
+}
+
+// GetPanel returns a panel with its jurors, criteria and the movies of its section.
+// Only jurors of the panel and roles with jury:manage can see it.
+func (ju *JuryUseCase) GetPanel(userID int, role string, panelID int) (*model.JuryPanel, error) {
+	panel, _, err := ju.getPanelAccess(userID, role, panelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if panel.Members, err = ju.JuryRepo.ListMembers(panelID); err != nil {
+		return nil, err
+	}
+	if panel.Criteria, err = ju.JuryRepo.ListCriteria(panelID); err != nil {
+		return nil, err
+	}
+	if panel.Movies, err = ju.JuryRepo.ListSectionMovies(panel.SectionID); err != nil {
+		return nil, err
+	}
+
+	return panel, nil
+}
+
+// DeletePanel deletes an open panel with its jurors, criteria and scores.
+func (ju *JuryUseCase) DeletePanel(panelID int) error {
+	if _, err := ju.getOpenPanel(panelID); err != nil {
+		return err
+	}
+
+	return ju.JuryRepo.DeletePanel(panelID)
+}
+
+// SaveMember adds a user to an open panel or changes their presidency. The user's role
+// must grant jury:score.
+func (ju *JuryUseCase) SaveMember(panelID, userID int, request *model.RequestJuryMember) (*model.JuryMember, error) {
+	if _, err := ju.getOpenPanel(panelID); err != nil {
+		return nil, err
+	}
+
+	user, err := ju.UsersRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+
+	juror, err := ju.RolesRepo.RoleHasPermission(user.Role, "jury:score")
+	if err != nil {
+		return nil, err
+	}
+	if !juror {
+		return nil, fmt.Errorf("user is not a juror")
+	}
+
+	if err := ju.JuryRepo.SaveMember(panelID, userID, request.IsPresident); err != nil {
+		return nil, err
+	}
+
+	return ju.JuryRepo.GetMember(panelID, userID)
+}
+
+// DeleteMember removes a juror and their scores from an open panel.
+func (ju *JuryUseCase) DeleteMember(panelID, userID int) error {
+	if _, err := ju.getOpenPanel(panelID); err != nil {
+		return err
+	}
+
+	if _, err := ju.JuryRepo.GetMember(panelID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("juror not found")
+		}
+		return err
+	}
+
+	return ju.JuryRepo.DeleteMember(panelID, userID)
+}
+
+// CreateCriterion adds a scoring criterion to an open panel. The weight defaults to 1.
+func (ju *JuryUseCase) CreateCriterion(panelID int, request *model.RequestJuryCriterion) (*model.JuryCriterion, error) {
+	if _, err := ju.getOpenPanel(panelID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("invalid criterion name")
+	}
+
+	weight := request.Weight
+	if weight == 0 {
+		weight = 1
+	}
+	if weight < 1 || weight > 100 {
+		return nil, fmt.Errorf("invalid weight")
+	}
+
+	taken, err := ju.JuryRepo.CriterionNameTaken(panelID, name)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("criterion name already exists")
+	}
+
+	criterion := &model.JuryCriterion{PanelID: panelID, Name: name, Weight: weight}
+	if err := ju.JuryRepo.CreateCriterion(criterion); err != nil {
+		return nil, err
+	}
+
+	return criterion, nil
+}
+
+// DeleteCriterion removes a criterion and its scores from an open panel.
+func (ju *JuryUseCase) DeleteCriterion(panelID, criterionID int) error {
+	if _, err := ju.getOpenPanel(panelID); err != nil {
+		return err
+	}
+
+	deleted, err := ju.JuryRepo.DeleteCriterion(panelID, criterionID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("criterion not found")
+	}
+
+	return nil
+}
+
+// ListUserScores returns the scores a juror gave on a panel. Jurors only see their own scores.
+func (ju *JuryUseCase) ListUserScores(userID, panelID int) ([]model.JuryScore, error) {
+	if _, err := ju.getMemberPanel(userID, panelID); err != nil {
+		return nil, err
+	}
+
+	return ju.JuryRepo.ListUserScores(panelID, userID)
+}
+
+// SaveScores stores a juror's scores (1 to 10) for a movie of the panel's section.
+// Scores can be changed until the panel is locked.
+func (ju *JuryUseCase) SaveScores(userID, panelID, movieID int, request *model.RequestJuryScores) ([]model.JuryScore, error) {
+	panel, err := ju.getMemberPanel(userID, panelID)
+	if err != nil {
+		return nil, err
+	}
+	if panel.LockedAt != nil {
+		return nil, fmt.Errorf("panel locked")
+	}
+
+	movies, err := ju.JuryRepo.ListSectionMovies(panel.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	inSection := false
+	for _, movie := range movies {
+		if movie.ID == movieID {
+			inSection = true
+			break
+		}
+	}
+	if !inSection {
+		return nil, fmt.Errorf("movie not in section")
+	}
+
+	criteria, err := ju.JuryRepo.ListCriteria(panelID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int]bool, len(criteria))
+	for _, criterion := range criteria {
+		known[criterion.ID] = true
+	}
+
+	if len(request.Scores) == 0 {
+		return nil, fmt.Errorf("invalid score")
+	}
+	seen := make(map[int]bool, len(request.Scores))
+	for _, score := range request.Scores {
+		if !known[score.CriterionID] {
+			return nil, fmt.Errorf("criterion not found")
+		}
+		if seen[score.CriterionID] || score.Score < 1 || score.Score > 10 {
+			return nil, fmt.Errorf("invalid score")
+		}
+		seen[score.CriterionID] = true
+	}
+
+	saved, err := ju.JuryRepo.SaveScores(panelID, userID, movieID, request.Scores)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		// The panel was locked while the scores were checked
+		return nil, fmt.Errorf("panel locked")
+	}
+
+	scores, err := ju.JuryRepo.ListUserScores(panelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	movieScores := []model.JuryScore{}
+	for _, score := range scores {
+		if score.MovieID == movieID {
+			movieScores = append(movieScores, score)
+		}
+	}
+
+	return movieScores, nil
+}
+
+// GetRankings returns the aggregate ranking of a panel's section. Only the panel's president
+// and roles with jury:manage can see it; other jurors never see each other's scores.
+func (ju *JuryUseCase) GetRankings(userID int, role string, panelID int) ([]model.JuryRanking, error) {
+	panel, err := ju.getPresidingPanel(userID, role, panelID)
+	if err != nil {
+		return nil, err
+	}
+
+	rankings, err := ju.JuryRepo.GetRankings(panelID, panel.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rankings {
+		rankings[i].Score = math.Round(rankings[i].Score*100) / 100
+		for j := range rankings[i].Criteria {
+			rankings[i].Criteria[j].Average = math.Round(rankings[i].Criteria[j].Average*100) / 100
+		}
+
+		// Equal scores share a rank
+		if i > 0 && rankings[i].Score == rankings[i-1].Score {
+			rankings[i].Rank = rankings[i-1].Rank
+		} else {
+			rankings[i].Rank = i + 1
+		}
+	}
+
+	return rankings, nil
+}
+
+// LockPanel finalizes a panel's scores so awards can be announced. Only the president and roles
+// with jury:manage can lock, and only once every juror scored every movie on every criterion.
+func (ju *JuryUseCase) LockPanel(userID int, role string, panelID int) (*model.JuryPanel, error) {
+	panel, err := ju.getPresidingPanel(userID, role, panelID)
+	if err != nil {
+		return nil, err
+	}
+	if panel.LockedAt != nil {
+		return nil, fmt.Errorf("panel locked")
+	}
+
+	criteria, err := ju.JuryRepo.ListCriteria(panelID)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) == 0 {
+		return nil, fmt.Errorf("panel has no criteria")
+	}
+
+	members, err := ju.JuryRepo.ListMembers(panelID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("panel has no jurors")
+	}
+
+	missing, open, err := ju.JuryRepo.LockPanel(panelID, panel.SectionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !open {
+		return nil, fmt.Errorf("panel locked")
+	}
+	if missing > 0 {
+		return nil, &JuryIncompleteError{Missing: missing}
+	}
+
+	return ju.getPanel(panelID)
+}
+
+// getPanel loads a panel, mapping a missing row to "panel not found".
+func (ju *JuryUseCase) getPanel(panelID int) (*model.JuryPanel, error) {
+	panel, err := ju.JuryRepo.GetPanel(panelID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("panel not found")
+		}
+		return nil, err
+	}
+
+	return panel, nil
+}
+
+// getOpenPanel loads a panel that is not locked yet.
+func (ju *JuryUseCase) getOpenPanel(panelID int) (*model.JuryPanel, error) {
+	panel, err := ju.getPanel(panelID)
+	if err != nil {
+		return nil, err
+	}
+	if panel.LockedAt != nil {
+		return nil, fmt.Errorf("panel locked")
+	}
+
+	return panel, nil
+}
+
+// getMemberPanel loads a panel the user sits on, reporting other panels as missing.
+func (ju *JuryUseCase) getMemberPanel(userID, panelID int) (*model.JuryPanel, error) {
+	panel, err := ju.getPanel(panelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ju.JuryRepo.GetMember(panelID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("panel not found")
+		}
+		return nil, err
+	}
+
+	return panel, nil
+}
+
+// getPanelAccess loads a panel visible to the user: jurors see their own panels and roles with
+// jury:manage see all of them. It returns the user's membership, nil for managers outside the panel.
+func (ju *JuryUseCase) getPanelAccess(userID int, role string, panelID int) (*model.JuryPanel, *model.JuryMember, error) {
+	panel, err := ju.getPanel(panelID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	member, err := ju.JuryRepo.GetMember(panelID, userID)
+	if err == nil {
+		return panel, member, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, err
+	}
+
+	manager, err := ju.RolesRepo.RoleHasPermission(role, "jury:manage")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !manager {
+		return nil, nil, fmt.Errorf("panel not found")
+	}
+
+	return panel, nil, nil
+}
+
+// getPresidingPanel loads a panel the user presides, or any panel for roles with jury:manage.
+func (ju *JuryUseCase) getPresidingPanel(userID int, role string, panelID int) (*model.JuryPanel, error) {
+	panel, member, err := ju.getPanelAccess(userID, role, panelID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.IsPresident {
+		return panel, nil
+	}
+
+	// Jurors who also manage juries keep their managing rights
+	manager, err := ju.RolesRepo.RoleHasPermission(role, "jury:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !manager {
+		return nil, fmt.Errorf("not jury president")
+	}
+
+	return panel, nil
+}
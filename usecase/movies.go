@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"movies/model"
@@ -8,11 +9,13 @@ import (
 )
 
 type MoviesUseCase struct {
-	MoviesRepo *repository.MoviesRepo
+	MoviesRepo          *repository.MoviesRepo
+	FestivalRepo        *repository.FestivalRepo
+	AvailabilityUseCase *AvailabilityUseCase
 }
 
-func NewMoviesUseCase(MoviesRepo *repository.MoviesRepo) *MoviesUseCase {
-	return &MoviesUseCase{MoviesRepo: MoviesRepo}
+func NewMoviesUseCase(MoviesRepo *repository.MoviesRepo, FestivalRepo *repository.FestivalRepo, AvailabilityUseCase *AvailabilityUseCase) *MoviesUseCase {
+	return &MoviesUseCase{MoviesRepo: MoviesRepo, FestivalRepo: FestivalRepo, AvailabilityUseCase: AvailabilityUseCase}
 }
 
 // Create creates a new movie by calling the repository method.
@@ -25,6 +28,16 @@ func (uc *MoviesUseCase) Create(Movie *model.Movies) (*model.Movies, error) {
 	if !movieExists {
 		return nil, fmt.Errorf("genre not found") // Returns error if genre doesn't exist
 	}
+
+	// Check the festival edition and programme section
+	if Movie.EditionID != nil || Movie.SectionID != nil {
+		editionID, err := uc.resolveAssignment(Movie.EditionID, Movie.SectionID)
+		if err != nil {
+			return nil, err
+		}
+		Movie.EditionID = &editionID
+	}
+
 	return uc.MoviesRepo.Create(Movie) // Calls repository to create movie
 }
 
@@ -50,18 +63,117 @@ func (uc *MoviesUseCase) Update(movieID int, genreID int, updates map[string]int
 		return fmt.Errorf("movie not found") // Returns error if movie doesn't exist
 	}
 
+	// Check the festival edition and programme section
+	if err := uc.checkAssignmentUpdate(movieID, updates); err != nil {
+		return err
+	}
+
 	return uc.MoviesRepo.Update(movieID, updates) // Calls repository to update movie
 }
 
 // GetAllMoviesWithPagination retrieves movies with pagination by calling the repository.
-func (uc *MoviesUseCase) GetAllMoviesWithPagination(page, limit int) ([]model.Movies, error) {
+// Movies unavailable from the viewer's IP are left out unless a role with movies:write includes them.
+func (uc *MoviesUseCase) GetAllMoviesWithPagination(page, limit, editionID int, role, ip string, includeUnavailable bool) ([]model.Movies, error) {
 	if page <= 0 || limit <= 0 {
 		return nil, errors.New("invalid page or limit") // Validates page and limit
 	}
-	return uc.MoviesRepo.GetAllMoviesWithPagination(page, limit) // Calls repository to get movies with pagination
+
+	availableIn, err := uc.AvailabilityUseCase.ListingFilter(role, ip, includeUnavailable)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.MoviesRepo.GetAllMoviesWithPagination(page, limit, editionID, availableIn) // Calls repository to get movies with pagination
+}
+
+// SearchMovies calls the repository to search movies by artist, genre_id, edition_id, or a combination.
+// Movies unavailable from the viewer's IP are left out unless a role with movies:write includes them.
+func (uc *MoviesUseCase) SearchMovies(title string, description string, artist string, genreID int, editionID int, role, ip string, includeUnavailable bool) ([]model.Movies, error) {
+	availableIn, err := uc.AvailabilityUseCase.ListingFilter(role, ip, includeUnavailable)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.MoviesRepo.SearchMovies(title, description, artist, genreID, editionID, availableIn) // Calls repository to search movies
+}
+
+// GetWatchableMovie returns a movie for playback after checking its availability window and the
+// country of the viewer's IP.
+func (uc *MoviesUseCase) GetWatchableMovie(movieID int, ip string) (*model.Movies, error) {
+	movie, err := uc.MoviesRepo.GetMovie(movieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("movie not found")
+		}
+		return nil, err
+	}
+
+	if _, err := uc.AvailabilityUseCase.CheckPlayback(movieID, ip); err != nil {
+		return nil, err
+	}
+
+	return movie, nil
 }
 
-// SearchMovies calls the repository to search movies by artist, genre_id, or both
-func (uc *MoviesUseCase) SearchMovies(title string, description string, artist string, genreID int) ([]model.Movies, error) {
-	return uc.MoviesRepo.SearchMovies(title, description, artist, genreID) // Calls repository to search movies
+// checkAssignmentUpdate validates edition_id and section_id in an update. A section also sets its edition,
+// and moving a movie to another edition clears its section, which belongs to the old edition.
+func (uc *MoviesUseCase) checkAssignmentUpdate(movieID int, updates map[string]interface{}) error {
+	sectionID, hasSection := updates["section_id"].(int)
+	editionID, hasEdition := updates["edition_id"].(int)
+	if !hasSection && !hasEdition {
+		return nil
+	}
+
+	var section, edition *int
+	if hasSection {
+		section = &sectionID
+	}
+	if hasEdition {
+		edition = &editionID
+	}
+
+	resolved, err := uc.resolveAssignment(edition, section)
+	if err != nil {
+		return err
+	}
+	updates["edition_id"] = resolved
+
+	if !hasSection {
+		current, err := uc.MoviesRepo.GetMovieEdition(movieID)
+		if err != nil {
+			return err
+		}
+		if current == nil || *current != resolved {
+			updates["section_id"] = nil
+		}
+	}
+
+	return nil
+}
+
+// resolveAssignment checks that the edition and section exist and belong together, and returns the
+// edition the movie is assigned to. A section without an edition assigns the section's edition.
+func (uc *MoviesUseCase) resolveAssignment(editionID, sectionID *int) (int, error) {
+	if sectionID != nil {
+		section, err := uc.FestivalRepo.GetSection(*sectionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, fmt.Errorf("section not found")
+			}
+			return 0, err
+		}
+		if editionID != nil && *editionID != section.EditionID {
+			return 0, fmt.Errorf("section not in edition")
+		}
+		return section.EditionID, nil
+	}
+
+	if _, err := uc.FestivalRepo.GetEdition(*editionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("edition not found")
+		}
+		return 0, err
+	}
+
+	return *editionID, nil
 }
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"movies/model"
+	"movies/oidc"
+	"movies/repository"
+	"movies/utils"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// oidcStateTTL bounds how long a user may take to log in at the provider.
+const oidcStateTTL = 10 * time.Minute
+
+type OIDCUseCase struct {
+	Provider     *oidc.Provider
+	OIDCRepo     *repository.OIDCRepo
+	UsersUseCase *UsersUseCase
+}
+
+func NewOIDCUseCase(Provider *oidc.Provider, OIDCRepo *repository.OIDCRepo, UsersUseCase *UsersUseCase) *OIDCUseCase {
+	return &OIDCUseCase{Provider: Provider, OIDCRepo: OIDCRepo, UsersUseCase: UsersUseCase}
+}
+
+// Enabled reports whether an OIDC provider is configured.
+func (ou *OIDCUseCase) Enabled() bool {
+	return ou.Provider != nil
+}
+
+// StartLogin creates a pending login and returns its state and the provider URL to redirect the user to.
+func (ou *OIDCUseCase) StartLogin(ctx context.Context) (string, string, error) {
+	if !ou.Enabled() {
+		return "", "", fmt.Errorf("oidc not configured")
+	}
+
+	state, err := utils.GenerateToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := utils.GenerateToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := ou.OIDCRepo.CreateLoginState(state, nonce, codeVerifier, time.Now().Add(oidcStateTTL)); err != nil {
+		return "", "", err
+	}
+
+	authURL, err := ou.Provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(codeVerifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	return state, authURL, nil
+}
+
+// FinishLogin completes a login from the provider callback and returns the user to issue tokens for.
+//
+// A known identity logs into its linked account. A new identity is linked to the account with the same
+// email only when the provider has verified that email; otherwise a new account is created.
+func (ou *OIDCUseCase) FinishLogin(ctx context.Context, state, code, ip string) (*model.Users, error) {
+	if !ou.Enabled() {
+		return nil, fmt.Errorf("oidc not configured")
+	}
+
+	nonce, codeVerifier, err := ou.OIDCRepo.ConsumeLoginState(state)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invalid state")
+		}
+		return nil, err
+	}
+
+	rawIDToken, err := ou.Provider.Exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("oidc exchange failed: %w", err)
+	}
+
+	claims, err := ou.Provider.VerifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("oidc exchange failed: %w", err)
+	}
+
+	user, err := ou.resolveUser(claims, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DisabledAt != nil {
+		return nil, fmt.Errorf("account disabled")
+	}
+
+	return user, nil
+}
+
+// resolveUser finds or creates the account of a verified ID token.
+func (ou *OIDCUseCase) resolveUser(claims *oidc.IDTokenClaims, ip string) (*model.Users, error) {
+	provider := ou.Provider.Name
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	usersRepo := ou.UsersUseCase.UsersRepo
+
+	// Known identity: log into the linked account
+	userID, err := ou.OIDCRepo.GetIdentityUserID(provider, claims.Subject)
+	if err == nil {
+		if err := ou.OIDCRepo.TouchIdentity(provider, claims.Subject, email); err != nil {
+			return nil, err
+		}
+		return usersRepo.GetUserByID(userID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if email == "" {
+		return nil, fmt.Errorf("oidc email missing")
+	}
+
+	existing, err := usersRepo.GetUser(&model.Users{Email: email})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var user *model.Users
+	if existing != nil {
+		// Linking on an unverified email would let anyone claiming the address take over the account
+		if !claims.EmailVerified {
+			return nil, fmt.Errorf("email already registered")
+		}
+		user = existing
+	} else {
+		user, err = ou.createUser(email, claims, ip)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ou.OIDCRepo.CreateIdentity(user.Id, provider, claims.Subject, email); err != nil {
+		return nil, err
+	}
+
+	if claims.EmailVerified {
+		if err := usersRepo.MarkEmailVerified(user.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// createUser registers a new account for an identity. The account gets an unusable random password,
+// so it can only log in through the provider until the user sets one with the password reset flow.
+func (ou *OIDCUseCase) createUser(email string, claims *oidc.IDTokenClaims, ip string) (*model.Users, error) {
+	randomPassword, err := utils.GenerateToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(randomPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user, err := ou.UsersUseCase.UsersRepo.Create(&model.Users{
+		Email:          email,
+		Password:       string(hashedPassword),
+		Role:           "user",
+		RegistrationIP: ip,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the provider's display name and picture as the starting profile
+	updates := make(map[string]interface{})
+	if name := strings.TrimSpace(claims.Name); name != "" && len(name) <= 100 {
+		updates["display_name"] = name
+	}
+	if claims.Picture != "" && isHTTPURL(claims.Picture) && len(claims.Picture) <= 500 {
+		updates["avatar_url"] = claims.Picture
+	}
+	if len(updates) > 0 {
+		if err := ou.UsersUseCase.UsersRepo.UpdateProfile(user.Id, updates); err != nil {
+			log.Println("ERR set oidc profile: ", err)
+		}
+	}
+
+	ou.UsersUseCase.AbuseUseCase.ScoreRegistration(user)
+
+	// Addresses the provider has not verified still need our own confirmation
+	if !claims.EmailVerified {
+		if err := ou.UsersUseCase.SendVerificationEmail(user); err != nil {
+			log.Println("ERR send verification email: ", err)
+		}
+	}
+
+	return user, nil
+}
@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"movies/model"
+	"movies/repository"
+	"movies/utils"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Weights used to compare two movies by content.
+const (
+	similarGenreWeight  = 3.0 // Both movies share the same genre
+	similarArtistWeight = 2.0 // Per artist appearing in both movies
+)
+
+// Weights of the user's interactions when building a content profile.
+const (
+	profileLikeWeight   = 2.0  // Movie the user liked
+	profileViewWeight   = 1.0  // Movie the user watched without voting
+	profileUnlikeWeight = -2.0 // Movie the user disliked
+)
+
+// Sources reported on each recommendation.
+const (
+	sourceCollaborative = "collaborative"
+	sourceContent       = "content"
+	sourcePopular       = "popular"
+)
+
+type RecommendationsUseCase struct {
+	RecommendationsRepo *repository.RecommendationsRepo
+	StatsRepo           *repository.StatsRepo
+	AvailabilityUseCase *AvailabilityUseCase
+	cache               *recommendationCache
+}
+
+func NewRecommendationsUseCase(RecommendationsRepo *repository.RecommendationsRepo, StatsRepo *repository.StatsRepo, AvailabilityUseCase *AvailabilityUseCase) *RecommendationsUseCase {
+	ttl := time.Duration(utils.GetEnvInt("RECOMMENDATIONS_CACHE_TTL_MINUTES", 10)) * time.Minute
+	return &RecommendationsUseCase{
+		RecommendationsRepo: RecommendationsRepo,
+		StatsRepo:           StatsRepo,
+		AvailabilityUseCase: AvailabilityUseCase,
+		cache:               newRecommendationCache(ttl),
+	}
+}
+
+// GetSimilarMovies ranks the catalog by content similarity to the given movie.
+// Movies unavailable from the viewer's IP are left out unless a role with movies:write includes them.
+func (uc *RecommendationsUseCase) GetSimilarMovies(movieID, limit int, role, ip string, includeUnavailable bool) ([]model.RecommendedMovie, error) {
+	cacheKey := fmt.Sprintf("movie:%d", movieID)
+	if cached, ok := uc.cache.get(cacheKey, ""); ok {
+		return uc.availableRecommendations(cached, limit, role, ip, includeUnavailable)
+	}
+
+	catalog, err := uc.RecommendationsRepo.GetCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load catalog: %w", err)
+	}
+
+	// Find the source movie in the catalog
+	var source *model.Movies
+	for i := range catalog {
+		if catalog[i].ID == movieID {
+			source = &catalog[i]
+			break
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("movie not found")
+	}
+
+	var results []model.RecommendedMovie
+	for _, candidate := range catalog {
+		if candidate.ID == movieID {
+			continue
+		}
+		if score := contentSimilarity(*source, candidate); score > 0 {
+			results = append(results, model.RecommendedMovie{Movies: candidate, Score: score, Source: sourceContent})
+		}
+	}
+	sortRecommendations(results)
+
+	uc.cache.set(cacheKey, "", results)
+	return uc.availableRecommendations(results, limit, role, ip, includeUnavailable)
+}
+
+// GetUserRecommendations suggests unseen movies to a user.
+// The trained collaborative model is tried first, then content similarity to the user's history,
+// and users without any history (cold start) get the most popular movies. Cached results are only
+// reused while the stored model is the one they were built from.
+// Movies unavailable from the viewer's IP are left out unless a role with movies:write includes them.
+func (uc *RecommendationsUseCase) GetUserRecommendations(userID, limit int, role, ip string, includeUnavailable bool) ([]model.RecommendedMovie, error) {
+	modelVersion, err := uc.RecommendationsRepo.GetModelVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load model version: %w", err)
+	}
+
+	cacheKey := fmt.Sprintf("user:%d", userID)
+	if cached, ok := uc.cache.get(cacheKey, modelVersion); ok {
+		return uc.availableRecommendations(cached, limit, role, ip, includeUnavailable)
+	}
+
+	catalog, profile, err := uc.loadUserProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := uc.collaborativeRecommendations(catalog, profile)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		results = contentRecommendations(catalog, profile)
+	}
+	if len(results) == 0 {
+		results, err = uc.popularRecommendations(catalog, profile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	uc.cache.set(cacheKey, modelVersion, results)
+	return uc.availableRecommendations(results, limit, role, ip, includeUnavailable)
+}
+
+// availableRecommendations drops the movies a viewer cannot watch now and keeps the first limit results.
+// Cached rankings cover the whole catalog, so availability is applied on every request.
+func (uc *RecommendationsUseCase) availableRecommendations(results []model.RecommendedMovie, limit int, role, ip string, includeUnavailable bool) ([]model.RecommendedMovie, error) {
+	availableIn, err := uc.AvailabilityUseCase.ListingFilter(role, ip, includeUnavailable)
+	if err != nil {
+		return nil, err
+	}
+	if availableIn == nil {
+		return truncateRecommendations(results, limit), nil
+	}
+
+	available, err := uc.RecommendationsRepo.GetAvailableMovieIDs(*availableIn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load available movies: %w", err)
+	}
+
+	filtered := make([]model.RecommendedMovie, 0, len(results))
+	for _, result := range results {
+		if available[result.ID] {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return truncateRecommendations(filtered, limit), nil
+}
+
+// InvalidateUser drops the cached recommendations of a user, e.g. after their votes change.
+func (uc *RecommendationsUseCase) InvalidateUser(userID int) {
+	uc.cache.delete(fmt.Sprintf("user:%d", userID))
+}
+
+// loadUserProfile loads the catalog and weights each movie the user interacted with; votes override plain views.
+func (uc *RecommendationsUseCase) loadUserProfile(userID int) ([]model.Movies, map[int]float64, error) {
+	catalog, err := uc.RecommendationsRepo.GetCatalog()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load catalog: %w", err)
+	}
+
+	votes, err := uc.RecommendationsRepo.GetUserVotes(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load user votes: %w", err)
+	}
+
+	viewed, err := uc.RecommendationsRepo.GetUserViewedMovieIDs(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load user views: %w", err)
+	}
+
+	profile := make(map[int]float64)
+	for _, movieID := range viewed {
+		profile[movieID] = profileViewWeight
+	}
+	for movieID, isLike := range votes {
+		if isLike {
+			profile[movieID] = profileLikeWeight
+		} else {
+			profile[movieID] = profileUnlikeWeight
+		}
+	}
+
+	return catalog, profile, nil
+}
+
+// collaborativeRecommendations scores unseen movies through the trained neighbours of the user's movies.
+func (uc *RecommendationsUseCase) collaborativeRecommendations(catalog []model.Movies, profile map[int]float64) ([]model.RecommendedMovie, error) {
+	movieIDs := make([]int, 0, len(profile))
+	for movieID := range profile {
+		movieIDs = append(movieIDs, movieID)
+	}
+
+	similarities, err := uc.RecommendationsRepo.GetSimilarities(movieIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load movie similarities: %w", err)
+	}
+
+	scores := make(map[int]float64)
+	for _, similarity := range similarities {
+		if _, seen := profile[similarity.SimilarMovieID]; seen {
+			continue
+		}
+		scores[similarity.SimilarMovieID] += profile[similarity.MovieID] * similarity.Score
+	}
+
+	var results []model.RecommendedMovie
+	for _, movie := range catalog {
+		if score := scores[movie.ID]; score > 0 {
+			results = append(results, model.RecommendedMovie{Movies: movie, Score: score, Source: sourceCollaborative})
+		}
+	}
+	sortRecommendations(results)
+
+	return results, nil
+}
+
+// popularRecommendations suggests the most played movies the user has not interacted with.
+func (uc *RecommendationsUseCase) popularRecommendations(catalog []model.Movies, profile map[int]float64) ([]model.RecommendedMovie, error) {
+	popular, err := uc.StatsRepo.GetPopularMovies(50 + len(profile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load popular movies: %w", err)
+	}
+
+	moviesByID := make(map[int]model.Movies, len(catalog))
+	for _, movie := range catalog {
+		moviesByID[movie.ID] = movie
+	}
+
+	var results []model.RecommendedMovie
+	for _, stats := range popular {
+		if _, seen := profile[stats.ID]; seen {
+			continue
+		}
+		if movie, ok := moviesByID[stats.ID]; ok {
+			results = append(results, model.RecommendedMovie{Movies: movie, Score: float64(stats.Plays), Source: sourcePopular})
+		}
+	}
+
+	return results, nil
+}
+
+// contentRecommendations scores every movie the user has not interacted with against their content profile.
+func contentRecommendations(catalog []model.Movies, profile map[int]float64) []model.RecommendedMovie {
+	moviesByID := make(map[int]model.Movies, len(catalog))
+	for _, movie := range catalog {
+		moviesByID[movie.ID] = movie
+	}
+
+	var results []model.RecommendedMovie
+	for _, candidate := range catalog {
+		if _, seen := profile[candidate.ID]; seen {
+			continue
+		}
+
+		var score float64
+		for movieID, weight := range profile {
+			if movie, ok := moviesByID[movieID]; ok {
+				score += weight * contentSimilarity(movie, candidate)
+			}
+		}
+		if score > 0 {
+			results = append(results, model.RecommendedMovie{Movies: candidate, Score: score, Source: sourceContent})
+		}
+	}
+	sortRecommendations(results)
+
+	return results
+}
+
+// Train builds the item-item collaborative filtering model from users' views and votes and stores it.
+// Each movie keeps its RECOMMENDER_NEIGHBORS (default 20) most similar movies by cosine similarity.
+// It returns the number of similarity rows stored.
+func (uc *RecommendationsUseCase) Train() (int, error) {
+	interactions, err := uc.RecommendationsRepo.GetInteractions()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load interactions: %w", err)
+	}
+
+	// Group ratings per user and accumulate each movie's vector norm
+	ratingsByUser := make(map[int]map[int]float64)
+	norms := make(map[int]float64)
+	for _, interaction := range interactions {
+		if interaction.Rating == 0 {
+			continue
+		}
+		if ratingsByUser[interaction.UserID] == nil {
+			ratingsByUser[interaction.UserID] = make(map[int]float64)
+		}
+		ratingsByUser[interaction.UserID][interaction.MovieID] = interaction.Rating
+		norms[interaction.MovieID] += interaction.Rating * interaction.Rating
+	}
+
+	// Accumulate dot products of every pair of movies rated by the same user
+	dots := make(map[[2]int]float64)
+	for _, ratings := range ratingsByUser {
+		for a, ratingA := range ratings {
+			for b, ratingB := range ratings {
+				if a < b {
+					dots[[2]int{a, b}] += ratingA * ratingB
+				}
+			}
+		}
+	}
+
+	neighbours := make(map[int][]model.MovieSimilarity)
+	for pair, dot := range dots {
+		score := dot / (math.Sqrt(norms[pair[0]]) * math.Sqrt(norms[pair[1]]))
+		if score <= 0 {
+			continue
+		}
+		neighbours[pair[0]] = append(neighbours[pair[0]], model.MovieSimilarity{MovieID: pair[0], SimilarMovieID: pair[1], Score: score})
+		neighbours[pair[1]] = append(neighbours[pair[1]], model.MovieSimilarity{MovieID: pair[1], SimilarMovieID: pair[0], Score: score})
+	}
+
+	// Keep only the closest neighbours of each movie
+	maxNeighbours := utils.GetEnvInt("RECOMMENDER_NEIGHBORS", 20)
+	var similarities []model.MovieSimilarity
+	for _, list := range neighbours {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Score != list[j].Score {
+				return list[i].Score > list[j].Score
+			}
+			return list[i].SimilarMovieID < list[j].SimilarMovieID
+		})
+		if len(list) > maxNeighbours {
+			list = list[:maxNeighbours]
+		}
+		similarities = append(similarities, list...)
+	}
+
+	if err := uc.RecommendationsRepo.ReplaceSimilarities(similarities); err != nil {
+		return 0, fmt.Errorf("failed to store similarities: %w", err)
+	}
+
+	return len(similarities), nil
+}
+
+// contentSimilarity scores how alike two movies are by genre and shared artists.
+func contentSimilarity(a, b model.Movies) float64 {
+	var score float64
+	if a.GenreID != nil && b.GenreID != nil && *a.GenreID == *b.GenreID {
+		score += similarGenreWeight
+	}
+
+	artists := make(map[string]bool)
+	for _, artist := range splitArtists(a.Artist) {
+		artists[artist] = true
+	}
+	for _, artist := range splitArtists(b.Artist) {
+		if artists[artist] {
+			score += similarArtistWeight
+		}
+	}
+
+	return score
+}
+
+// splitArtists normalizes the free-text artist column into a list of names.
+func splitArtists(artist string) []string {
+	fields := strings.FieldsFunc(artist, func(r rune) bool {
+		return r == ',' || r == ';' || r == '&' || r == '/'
+	})
+
+	var names []string
+	for _, field := range fields {
+		if name := strings.ToLower(strings.TrimSpace(field)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sortRecommendations orders recommendations by score, then by movie ID for stable output.
+func sortRecommendations(results []model.RecommendedMovie) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+}
+
+// truncateRecommendations returns at most limit recommendations.
+func truncateRecommendations(results []model.RecommendedMovie, limit int) []model.RecommendedMovie {
+	if limit > 0 && len(results) > limit {
+		return results[:limit]
+	}
+	return results
+}
+
+// recommendationCache keeps computed recommendation lists in memory for a limited time.
+type recommendationCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]recommendationCacheEntry
+}
+
+type recommendationCacheEntry struct {
+	results      []model.RecommendedMovie
+	modelVersion string
+	expiresAt    time.Time
+}
+
+func newRecommendationCache(ttl time.Duration) *recommendationCache {
+	return &recommendationCache{ttl: ttl, entries: make(map[string]recommendationCacheEntry)}
+}
+
+// get returns unexpired results cached for key that were built from modelVersion.
+func (c *recommendationCache) get(key, modelVersion string) ([]model.RecommendedMovie, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.modelVersion != modelVersion || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.results, true
+}
+
+func (c *recommendationCache) set(key, modelVersion string, results []model.RecommendedMovie) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = recommendationCacheEntry{results: results, modelVersion: modelVersion, expiresAt: time.Now().Add(c.ttl)}
+}
+
+func (c *recommendationCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"database/sql/driver"
+	"errors"
+	"movies/geo"
+	"movies/internal/fakedb"
+	"movies/repository"
+	"testing"
+)
+
+func TestGetSimilarMoviesLeavesOutUnavailableMovies(t *testing.T) {
+	// Movie 3 shares the genre of movie 1 but its availability window has ended
+	catalog := [][]driver.Value{
+		{int64(1), "Source", "", "1h 30m", "Director A", int64(7), "https://example.com/1"},
+		{int64(2), "Available", "", "1h 30m", "Director B", int64(7), "https://example.com/2"},
+		{int64(3), "Expired", "", "1h 30m", "Director A", int64(7), "https://example.com/3"},
+	}
+
+	db := fakedb.Open(func(stmt *fakedb.Statement) (*fakedb.Result, error) {
+		switch {
+		case stmt.Table == "movies" && stmt.Mentions("movie_availability"):
+			return &fakedb.Result{Rows: [][]driver.Value{{int64(1)}, {int64(2)}}}, nil
+		case stmt.Table == "movies" && stmt.Verb == "SELECT":
+			return &fakedb.Result{Rows: catalog}, nil
+		}
+
+		t.Errorf("unexpected %s on %s", stmt.Verb, stmt.Table)
+		return nil, errors.New("unexpected statement")
+	})
+
+	availabilityUseCase := NewAvailabilityUseCase(repository.NewAvailabilityRepo(db), repository.NewMoviesRepo(db), repository.NewRolesRepo(db), geo.UnknownResolver{})
+	recommendationsUseCase := NewRecommendationsUseCase(repository.NewRecommendationsRepo(db), repository.NewStatsRepo(db), availabilityUseCase)
+
+	// The second request is served from the cache, which must be filtered as well
+	for request := 1; request <= 2; request++ {
+		movies, err := recommendationsUseCase.GetSimilarMovies(1, 10, "", "203.0.113.7", false)
+		if err != nil {
+			t.Fatalf("request %d: %v", request, err)
+		}
+		if len(movies) != 1 || movies[0].ID != 2 {
+			t.Fatalf("request %d: expected only movie 2, got %+v", request, movies)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"movies/repository"
+	"movies/utils"
+	"strings"
+	"time"
+)
+
+// ScreeningConflictError is returned when a screening overlaps another one at the same venue.
+type ScreeningConflictError struct {
+	Conflict *model.Screening // The screening already scheduled in the slot
+}
+
+func (e *ScreeningConflictError) Error() string {
+	return "screening conflict"
+}
+
+type ScreeningsUseCase struct {
+	ScreeningsRepo *repository.ScreeningsRepo
+	MoviesRepo     *repository.MoviesRepo
+}
+
+func NewScreeningsUseCase(ScreeningsRepo *repository.ScreeningsRepo, MoviesRepo *repository.MoviesRepo) *ScreeningsUseCase {
+	return &ScreeningsUseCase{ScreeningsRepo: ScreeningsRepo, MoviesRepo: MoviesRepo}
+}
+
+// ListVenues returns all venues.
+func (su *ScreeningsUseCase) ListVenues() ([]model.Venue, error) {
+	return su.ScreeningsRepo.ListVenues()
+}
+
+// CreateVenue validates and stores a new venue.
+func (su *ScreeningsUseCase) CreateVenue(request *model.RequestVenue) (*model.Venue, error) {
+	venue, err := su.validateVenue(0, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := su.ScreeningsRepo.CreateVenue(venue); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+// UpdateVenue validates and replaces the details of a venue. The capacity cannot drop below the seats
+// already sold for an upcoming screening.
+func (su *ScreeningsUseCase) UpdateVenue(venueID int, request *model.RequestVenue) (*model.Venue, error) {
+	if _, err := su.getVenue(venueID); err != nil {
+		return nil, err
+	}
+
+	venue, err := su.validateVenue(venueID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := su.ScreeningsRepo.UpdateVenue(venue); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+// DeleteVenue deletes a venue that has no screenings.
+func (su *ScreeningsUseCase) DeleteVenue(venueID int) error {
+	if _, err := su.getVenue(venueID); err != nil {
+		return err
+	}
+
+	count, err := su.ScreeningsRepo.CountVenueScreenings(venueID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("venue has screenings")
+	}
+
+	return su.ScreeningsRepo.DeleteVenue(venueID)
+}
+
+// GetSchedule returns the screenings starting on a date (YYYY-MM-DD, in the server's time zone),
+// today when the date is empty. A non-zero venueID limits the schedule to one venue.
+func (su *ScreeningsUseCase) GetSchedule(date string, venueID int) ([]model.Screening, error) {
+	day := time.Now()
+	if date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date")
+		}
+		day = parsed
+	}
+
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	return su.ScreeningsRepo.ListScreenings(from, from.AddDate(0, 0, 1), venueID)
+}
+
+// GetScreening returns a screening by ID.
+func (su *ScreeningsUseCase) GetScreening(screeningID int) (*model.Screening, error) {
+	screening, err := su.ScreeningsRepo.GetScreening(screeningID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("screening not found")
+		}
+		return nil, err
+	}
+
+	return screening, nil
+}
+
+// CreateScreening schedules a movie at a venue.
+func (su *ScreeningsUseCase) CreateScreening(request *model.RequestScreening) (*model.Screening, error) {
+	return su.saveScreening(0, request)
+}
+
+// UpdateScreening moves or changes a scheduled screening. Once seats are taken only its language
+// and subtitles can change.
+func (su *ScreeningsUseCase) UpdateScreening(screeningID int, request *model.RequestScreening) (*model.Screening, error) {
+	if _, err := su.GetScreening(screeningID); err != nil {
+		return nil, err
+	}
+
+	return su.saveScreening(screeningID, request)
+}
+
+// DeleteScreening removes a screening from the schedule. Screenings that sold tickets are kept
+// so ticket holders can still see what they booked.
+func (su *ScreeningsUseCase) DeleteScreening(screeningID int) error {
+	if _, err := su.GetScreening(screeningID); err != nil {
+		return err
+	}
+
+	count, err := su.ScreeningsRepo.CountScreeningTickets(screeningID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("screening has tickets")
+	}
+
+	return su.ScreeningsRepo.DeleteScreening(screeningID)
+}
+
+// saveScreening validates a screening and stores it unless it overlaps another screening at the venue.
+// Screenings at a venue are kept SCREENING_CLEANUP_MINUTES (default 15) apart.
+func (su *ScreeningsUseCase) saveScreening(screeningID int, request *model.RequestScreening) (*model.Screening, error) {
+	language := strings.TrimSpace(request.Language)
+	subtitles := strings.TrimSpace(request.Subtitles)
+	if len(language) > 50 || len(subtitles) > 50 {
+		return nil, fmt.Errorf("invalid language")
+	}
+
+	movie, err := su.MoviesRepo.GetMovie(request.MovieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("movie not found")
+		}
+		return nil, err
+	}
+
+	venue, err := su.getVenue(request.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The slot is as long as the movie, so its duration must be readable
+	runtime, err := utils.ParseMovieDuration(movie.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("movie duration unknown")
+	}
+
+	startsAt := request.StartsAt.In(time.Local).Truncate(time.Minute)
+	screening := &model.Screening{
+		ID:         screeningID,
+		MovieID:    movie.ID,
+		MovieTitle: movie.Title,
+		VenueID:    venue.ID,
+		VenueName:  venue.Name,
+		StartsAt:   startsAt,
+		EndsAt:     startsAt.Add(runtime),
+		Language:   language,
+		Subtitles:  subtitles,
+	}
+
+	buffer := time.Duration(utils.GetEnvInt("SCREENING_CLEANUP_MINUTES", 15)) * time.Minute
+	conflict, err := su.ScreeningsRepo.SaveScreening(screening, buffer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("venue not found")
+		}
+		return nil, err
+	}
+	if conflict != nil {
+		return nil, &ScreeningConflictError{Conflict: conflict}
+	}
+
+	return screening, nil
+}
+
+// validateVenue checks a venue payload and returns the venue to store.
+func (su *ScreeningsUseCase) validateVenue(venueID int, request *model.RequestVenue) (*model.Venue, error) {
+	name := strings.TrimSpace(request.Name)
+	address := strings.TrimSpace(request.Address)
+	if name == "" || len(name) > 100 || len(address) > 255 {
+		return nil, fmt.Errorf("invalid venue")
+	}
+	if request.Capacity <= 0 {
+		return nil, fmt.Errorf("invalid capacity")
+	}
+
+	taken, err := su.ScreeningsRepo.VenueNameTaken(name, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("venue name already exists")
+	}
+
+	return &model.Venue{ID: venueID, Name: name, Capacity: request.Capacity, Address: address}, nil
+}
+
+// getVenue loads a venue, mapping a missing row to "venue not found".
+func (su *ScreeningsUseCase) getVenue(venueID int) (*model.Venue, error) {
+	venue, err := su.ScreeningsRepo.GetVenue(venueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("venue not found")
+		}
+		return nil, err
+	}
+
+	return venue, nil
+}
@@ -1,35 +1,49 @@
 package usecase
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"movies/model"
 	"movies/repository"
+	"movies/utils"
 )
 
 type StatsUseCase struct {
-	StatsRepo *repository.StatsRepo
+	StatsRepo              *repository.StatsRepo
+	AbuseUseCase           *AbuseUseCase
+	RecommendationsUseCase *RecommendationsUseCase
+	FestivalUseCase        *FestivalUseCase
+	AvailabilityUseCase    *AvailabilityUseCase
 }
 
-func NewStatsUseCase(StatsRepo *repository.StatsRepo) *StatsUseCase {
-	return &StatsUseCase{StatsRepo: StatsRepo}
+func NewStatsUseCase(StatsRepo *repository.StatsRepo, AbuseUseCase *AbuseUseCase, RecommendationsUseCase *RecommendationsUseCase, FestivalUseCase *FestivalUseCase, AvailabilityUseCase *AvailabilityUseCase) *StatsUseCase {
+	return &StatsUseCase{StatsRepo: StatsRepo, AbuseUseCase: AbuseUseCase, RecommendationsUseCase: RecommendationsUseCase, FestivalUseCase: FestivalUseCase, AvailabilityUseCase: AvailabilityUseCase}
 }
 
-func (uc *StatsUseCase) GetMostViewedStats() (*model.StatsModelView, error) {
+// GetMostViewedStats returns the most viewed movies and genres of a festival edition.
+// The edition is resolved by FestivalUseCase.ResolveEdition, so an empty value means the current edition.
+func (uc *StatsUseCase) GetMostViewedStats(edition string) (*model.StatsModelView, error) {
+	editionID, err := uc.FestivalUseCase.ResolveEdition(edition)
+	if err != nil {
+		return nil, err
+	}
+
 	// Fetch most viewed movies
-	movies, err := uc.StatsRepo.GetMostViewedMovies()
+	movies, err := uc.StatsRepo.GetMostViewedMovies(editionFilter(editionID))
 	if err != nil {
 		return nil, errors.New("failed to fetch most viewed movies")
 	}
 
 	// Fetch most viewed genres
-	genres, err := uc.StatsRepo.GetMostViewedGenres()
+	genres, err := uc.StatsRepo.GetMostViewedGenres(editionFilter(editionID))
 	if err != nil {
 		return nil, errors.New("failed to fetch most viewed genres")
 	}
 
 	// Return the combined statistics
 	stats := &model.StatsModelView{
+		EditionID:       editionID,
 		MostViewedMovie: movies,
 		MostViewedGenre: genres,
 	}
@@ -37,21 +51,28 @@ func (uc *StatsUseCase) GetMostViewedStats() (*model.StatsModelView, error) {
 	return stats, nil
 }
 
-func (uc *StatsUseCase) GetMostVotedStats() (*model.StatsModelVote, error) {
+// GetMostVotedStats returns the most voted movies and most viewed genres of a festival edition.
+func (uc *StatsUseCase) GetMostVotedStats(edition string) (*model.StatsModelVote, error) {
+	editionID, err := uc.FestivalUseCase.ResolveEdition(edition)
+	if err != nil {
+		return nil, err
+	}
+
 	// Fetch most voted movies
-	movies, err := uc.StatsRepo.GetMostVotedMovies()
+	movies, err := uc.StatsRepo.GetMostVotedMovies(editionFilter(editionID))
 	if err != nil {
 		return nil, errors.New("failed to fetch most voted movies")
 	}
 
 	// Fetch most viewed genres
-	genres, err := uc.StatsRepo.GetMostViewedGenres()
+	genres, err := uc.StatsRepo.GetMostViewedGenres(editionFilter(editionID))
 	if err != nil {
 		return nil, errors.New("failed to fetch most viewed genres")
 	}
 
 	// Return the combined statistics
 	stats := &model.StatsModelVote{
+		EditionID:       editionID,
 		MostVotedMovie:  movies,
 		MostViewedGenre: genres,
 	}
@@ -60,7 +81,7 @@ func (uc *StatsUseCase) GetMostVotedStats() (*model.StatsModelVote, error) {
 }
 
 // VoteMovie handles the logic for voting a movie
-func (uc *StatsUseCase) VoteMovie(userID, movieID int) (map[string]interface{}, error) {
+func (uc *StatsUseCase) VoteMovie(userID, movieID int, ip string) (map[string]interface{}, error) {
 	// Check if movie exists
 	movieExists, err := uc.StatsRepo.MovieExists(movieID)
 	if err != nil {
@@ -94,11 +115,17 @@ func (uc *StatsUseCase) VoteMovie(userID, movieID int) (map[string]interface{},
 	}
 
 	// Add vote to the database
-	err = uc.StatsRepo.AddVote(userID, movieID)
+	err = uc.StatsRepo.AddVote(userID, movieID, ip)
 	if err != nil {
 		return nil, err
 	}
 
+	// Score the vote so suspicious likes are kept out of the leaderboards
+	uc.AbuseUseCase.ScoreVote(userID, movieID, ip)
+
+	// The user's taste changed, so cached recommendations are stale
+	uc.RecommendationsUseCase.InvalidateUser(userID)
+
 	// Return success message
 	return map[string]interface{}{
 		"message": "Movie voted successfully",
@@ -146,6 +173,9 @@ func (uc *StatsUseCase) UnvoteMovie(userID, movieID int) (map[string]interface{}
 		return nil, err
 	}
 
+	// The user's taste changed, so cached recommendations are stale
+	uc.RecommendationsUseCase.InvalidateUser(userID)
+
 	// Return success message
 	return map[string]interface{}{
 		"message": "Movie unvote successfully",
@@ -206,33 +236,118 @@ func (uc *StatsUseCase) TraceViewership(userID, movieID, duration int) error {
 	return nil
 }
 
-// TrackMovieView saves the viewership record into the database
-func (uc *StatsUseCase) TrackMovieView(view *model.MovieView) (bool, error) {
+// TrackMovieView starts a new playback session and saves it into the database.
+// The movie must be available from the viewer's IP, and a movie with max_viewers only gets a new
+// session while it has fewer active viewers; sessions without a heartbeat in the last
+// CONCURRENT_VIEWER_IDLE_MINUTES (default 5) stop counting.
+// Anonymous visitors are deduplicated: a repeat view inside VIEW_DEDUP_WINDOW_MINUTES returns the
+// existing session and reports false instead of recording a new one.
+func (uc *StatsUseCase) TrackMovieView(view *model.MovieView) (*model.MovieView, bool, error) {
 	// Check movies
 	movieExists, err := uc.StatsRepo.MovieExists(view.MovieID) // Checks if movie exists
 	if err != nil {
-		return false, fmt.Errorf("failed to validate movie existence: %w", err)
+		return nil, false, fmt.Errorf("failed to validate movie existence: %w", err)
 	}
 	if !movieExists {
-		return false, fmt.Errorf("movie not found") // Returns error if movie doesn't exist
+		return nil, false, fmt.Errorf("movie not found") // Returns error if movie doesn't exist
 	}
 
-	// Check if user has already viewed the movie
-	hasViewed, err := uc.StatsRepo.HasViewed(view.MovieID, view.UserID) // Checks if user has viewed the movie
+	// Check the availability window and the viewer's country
+	availability, err := uc.AvailabilityUseCase.CheckPlayback(view.MovieID, view.IPAddress)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
-	// If user has already viewed, return true
-	if hasViewed {
-		return true, nil
+	// Check for a recent view by the same anonymous visitor
+	if view.UserID == 0 {
+		window := utils.GetEnvInt("VIEW_DEDUP_WINDOW_MINUTES", 30)
+		recent, err := uc.StatsRepo.GetRecentVisitorView(view.MovieID, view.VisitorID, window)
+		if err == nil {
+			return recent, false, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, fmt.Errorf("failed to check recent views: %w", err)
+		}
 	}
 
-	// Save the new view record to database
-	err = uc.StatsRepo.SaveMovieView(view) // Calls repository to save view
+	// Every play gets its own session so rewatches are counted
+	sessionID, err := utils.GenerateToken(16)
 	if err != nil {
-		return false, err
+		return nil, false, fmt.Errorf("failed to generate session id: %w", err)
+	}
+	view.SessionID = sessionID
+
+	// Save the new view record to database, within the concurrent viewer limit if the movie has one
+	if availability != nil && availability.MaxViewers != nil {
+		saved, err := uc.StatsRepo.SaveMovieViewWithinLimit(view, *availability.MaxViewers, utils.GetEnvInt("CONCURRENT_VIEWER_IDLE_MINUTES", 5))
+		if err != nil {
+			return nil, false, err
+		}
+		if !saved {
+			return nil, false, fmt.Errorf("movie at capacity")
+		}
+	} else if err := uc.StatsRepo.SaveMovieView(view); err != nil { // Calls repository to save view
+		return nil, false, err
+	}
+
+	// Score the view so suspicious traffic is kept out of the leaderboards
+	uc.AbuseUseCase.ScoreView(view)
+
+	return view, true, nil
+}
+
+// HeartbeatSession records watched seconds for an open playback session owned by the viewer
+func (uc *StatsUseCase) HeartbeatSession(userID int, visitorID string, sessionID string, duration int) error {
+	if _, err := uc.getOpenSession(userID, visitorID, sessionID); err != nil {
+		return err
+	}
+
+	if err := uc.StatsRepo.HeartbeatViewSession(sessionID, duration); err != nil {
+		return fmt.Errorf("failed to record heartbeat: %w", err)
 	}
 
-	return false, nil
+	return nil
+}
+
+// EndSession closes a playback session owned by the viewer
+func (uc *StatsUseCase) EndSession(userID int, visitorID string, sessionID string, duration int) error {
+	if _, err := uc.getOpenSession(userID, visitorID, sessionID); err != nil {
+		return err
+	}
+
+	if err := uc.StatsRepo.EndViewSession(sessionID, duration); err != nil {
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+
+	return nil
+}
+
+// getOpenSession loads a session and checks that it belongs to the viewer and is still open.
+// Registered viewers are matched by user ID, anonymous ones by visitor ID.
+func (uc *StatsUseCase) getOpenSession(userID int, visitorID string, sessionID string) (*model.MovieView, error) {
+	session, err := uc.StatsRepo.GetViewSession(sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("session not found")
+		}
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	// Sessions of other viewers are reported as missing
+	if session.UserID != userID || (userID == 0 && session.VisitorID != visitorID) {
+		return nil, fmt.Errorf("session not found")
+	}
+	if session.EndedAt != nil {
+		return nil, fmt.Errorf("session already ended")
+	}
+
+	return session, nil
+}
+
+// editionFilter converts a resolved edition into the repository filter, where zero means all editions.
+func editionFilter(editionID *int) int {
+	if editionID == nil {
+		return 0
+	}
+	return *editionID
 }
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"database/sql/driver"
+	"errors"
+	"movies/geo"
+	"movies/internal/fakedb"
+	"movies/model"
+	"movies/repository"
+	"testing"
+	"time"
+)
+
+// fakeView is one movie_views row of the fake database.
+type fakeView struct {
+	id        int
+	sessionID string
+	movieID   int
+	visitorID string
+	ip        string
+	viewedAt  string
+	endedAt   *string
+}
+
+// newViewTrackingUseCase fakes the tables used to track the views of movie 5, which has no availability
+// rules. Every recorded view counts as recent, as the test runs well inside the dedup window.
+func newViewTrackingUseCase(t *testing.T, views *[]*fakeView) *StatsUseCase {
+	db := fakedb.Open(func(stmt *fakedb.Statement) (*fakedb.Result, error) {
+		switch {
+		case stmt.Table == "movies" && stmt.Verb == "SELECT":
+			exists := int64(0)
+			if stmt.Int("id") == 5 {
+				exists = 1
+			}
+			return &fakedb.Result{Rows: [][]driver.Value{{exists}}}, nil
+		case stmt.Table == "movie_availability" && stmt.Verb == "SELECT":
+			return nil, nil
+		case stmt.Table == "movie_views" && stmt.Verb == "INSERT":
+			view := &fakeView{id: len(*views) + 1, sessionID: stmt.String("session_id"), movieID: stmt.Int("movie_id"),
+				visitorID: stmt.String("visitor_id"), ip: stmt.String("ip_address"), viewedAt: stmt.String("viewed_at")}
+			*views = append(*views, view)
+			return &fakedb.Result{RowsAffected: 1, LastInsertID: int64(view.id)}, nil
+		case stmt.Table == "movie_views" && stmt.Verb == "SELECT" && stmt.Args["ip_address"] != nil:
+			var count int64
+			for _, view := range *views {
+				if view.ip == stmt.String("ip_address") {
+					count++
+				}
+			}
+			return &fakedb.Result{Rows: [][]driver.Value{{count}}}, nil
+		case stmt.Table == "movie_views" && stmt.Verb == "SELECT":
+			// Latest session by session ID, or by visitor and movie
+			for i := len(*views) - 1; i >= 0; i-- {
+				view := (*views)[i]
+				if stmt.Args["session_id"] != nil && view.sessionID != stmt.String("session_id") {
+					continue
+				}
+				if stmt.Args["visitor_id"] != nil && (view.visitorID != stmt.String("visitor_id") || view.movieID != stmt.Int("movie_id")) {
+					continue
+				}
+
+				var endedAt driver.Value
+				if view.endedAt != nil {
+					endedAt = *view.endedAt
+				}
+				return &fakedb.Result{Rows: [][]driver.Value{{int64(view.id), view.sessionID, int64(view.movieID), nil, view.visitorID,
+					view.viewedAt, endedAt, endedAt, int64(0)}}}, nil
+			}
+			return nil, nil
+		case stmt.Table == "movie_views" && stmt.Verb == "UPDATE":
+			// The test only updates sessions to end them
+			for _, view := range *views {
+				if view.sessionID == stmt.String("session_id") && view.endedAt == nil {
+					endedAt := time.Now().Format(time.DateTime)
+					view.endedAt = &endedAt
+					return &fakedb.Result{RowsAffected: 1}, nil
+				}
+			}
+			return nil, nil
+		}
+
+		t.Errorf("unexpected %s on %s", stmt.Verb, stmt.Table)
+		return nil, errors.New("unexpected statement")
+	})
+
+	availabilityUseCase := NewAvailabilityUseCase(repository.NewAvailabilityRepo(db), repository.NewMoviesRepo(db), repository.NewRolesRepo(db), geo.UnknownResolver{})
+	return NewStatsUseCase(repository.NewStatsRepo(db), NewAbuseUseCase(repository.NewAbuseRepo(db)), nil, nil, availabilityUseCase)
+}
+
+func TestTrackMovieViewDedupesVisitorAfterSessionEnded(t *testing.T) {
+	var views []*fakeView
+	statsUseCase := newViewTrackingUseCase(t, &views)
+
+	newView := func() *model.MovieView {
+		return &model.MovieView{MovieID: 5, VisitorID: "visitor-1", IPAddress: "203.0.113.7", ViewedAt: time.Now().Format(time.RFC3339)}
+	}
+
+	first, created, err := statsUseCase.TrackMovieView(newView())
+	if err != nil || !created {
+		t.Fatalf("expected the first view to be recorded, got created=%v err=%v", created, err)
+	}
+
+	if err := statsUseCase.EndSession(0, "visitor-1", first.SessionID, 60); err != nil {
+		t.Fatalf("failed to end the session: %v", err)
+	}
+
+	again, created, err := statsUseCase.TrackMovieView(newView())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || again.SessionID != first.SessionID {
+		t.Fatalf("expected the ended session %q to be returned, got created=%v session %q", first.SessionID, created, again.SessionID)
+	}
+	if len(views) != 1 {
+		t.Fatalf("expected one recorded view, got %d", len(views))
+	}
+}
@@ -0,0 +1,463 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"movies/model"
+	"movies/repository"
+	"strings"
+)
+
+// submissionTransitions lists the statuses a submission can move to from each status.
+// Decisions can be reopened by moving the submission back into review.
+var submissionTransitions = map[string][]string{
+	model.SubmissionStatusReceived: {model.SubmissionStatusInReview, model.SubmissionStatusRejected},
+	model.SubmissionStatusInReview: {model.SubmissionStatusSelected, model.SubmissionStatusRejected},
+	model.SubmissionStatusSelected: {model.SubmissionStatusInReview},
+	model.SubmissionStatusRejected: {model.SubmissionStatusInReview},
+}
+
+type SubmissionsUseCase struct {
+	SubmissionsRepo *repository.SubmissionsRepo
+	MoviesRepo      *repository.MoviesRepo
+	FestivalRepo    *repository.FestivalRepo
+	UsersRepo       *repository.UsersRepo
+	RolesRepo       *repository.RolesRepo
+}
+
+func NewSubmissionsUseCase(SubmissionsRepo *repository.SubmissionsRepo, MoviesRepo *repository.MoviesRepo, FestivalRepo *repository.FestivalRepo, UsersRepo *repository.UsersRepo, RolesRepo *repository.RolesRepo) *SubmissionsUseCase {
+	return &SubmissionsUseCase{SubmissionsRepo: SubmissionsRepo, MoviesRepo: MoviesRepo, FestivalRepo: FestivalRepo, UsersRepo: UsersRepo, RolesRepo: RolesRepo}
+}
+
+// Submit validates and stores a filmmaker's submission for an edition, optionally for one of its sections.
+func (su *SubmissionsUseCase) Submit(submission *model.Submission) (*model.Submission, error) {
+	submission.Title = strings.TrimSpace(submission.Title)
+	if submission.Title == "" || len(submission.Title) > 255 || len(submission.Duration) > 10 {
+		return nil, fmt.Errorf("invalid submission")
+	}
+
+	if submission.GenreID != nil {
+		genreExists, err := su.MoviesRepo.GenreExists(*submission.GenreID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate genre existence: %w", err)
+		}
+		if !genreExists {
+			return nil, fmt.Errorf("genre not found")
+		}
+	}
+
+	if _, err := su.FestivalRepo.GetEdition(submission.EditionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("edition not found")
+		}
+		return nil, err
+	}
+
+	if submission.SectionID != nil {
+		section, err := su.FestivalRepo.GetSection(*submission.SectionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("section not found")
+			}
+			return nil, err
+		}
+		if section.EditionID != submission.EditionID {
+			return nil, fmt.Errorf("section not in edition")
+		}
+	}
+
+	if err := su.SubmissionsRepo.Create(submission); err != nil {
+		return nil, err
+	}
+
+	return su.getSubmission(submission.ID)
+}
+
+// ListUserSubmissions returns the submissions of a filmmaker.
+func (su *SubmissionsUseCase) ListUserSubmissions(userID int) ([]model.Submission, error) {
+	return su.SubmissionsRepo.ListUserSubmissions(userID)
+}
+
+// GetUserSubmission returns a filmmaker's own submission with the notes shared with them.
+func (su *SubmissionsUseCase) GetUserSubmission(userID, submissionID int) (*model.Submission, error) {
+	submission, err := su.getSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserID != userID {
+		return nil, fmt.Errorf("submission not found")
+	}
+
+	if submission.Notes, err = su.SubmissionsRepo.ListNotes(submissionID, true); err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// ListSubmissions returns the submissions to review, optionally of one edition and status.
+// Filmmaker identities are hidden in blind editions unless the role leads the review.
+func (su *SubmissionsUseCase) ListSubmissions(role string, editionID int, status string) ([]model.Submission, error) {
+	if _, known := submissionTransitions[status]; status != "" && !known {
+		return nil, fmt.Errorf("invalid status")
+	}
+
+	submissions, err := su.SubmissionsRepo.ListSubmissions(editionID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := su.hideSubmittersFrom(role, submissions); err != nil {
+		return nil, err
+	}
+
+	return submissions, nil
+}
+
+// GetSubmission returns a submission with all programmer notes. Review leads also get the reviewer
+// assignments and conflicts of interest; other reviewers get no filmmaker identity in blind editions.
+func (su *SubmissionsUseCase) GetSubmission(role string, submissionID int) (*model.Submission, error) {
+	submission, err := su.getSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.Notes, err = su.SubmissionsRepo.ListNotes(submissionID, false); err != nil {
+		return nil, err
+	}
+
+	lead, err := su.isReviewLead(role)
+	if err != nil {
+		return nil, err
+	}
+	if !lead {
+		submissions := []model.Submission{*submission}
+		if err := su.hideSubmitters(submissions); err != nil {
+			return nil, err
+		}
+		return &submissions[0], nil
+	}
+
+	if submission.Reviews, err = su.SubmissionsRepo.ListReviews(submissionID); err != nil {
+		return nil, err
+	}
+	if submission.Conflicts, err = su.SubmissionsRepo.ListConflicts(submissionID); err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// UpdateStatus moves a submission along its review: received, in_review, then selected or rejected.
+// Converted submissions keep their status.
+func (su *SubmissionsUseCase) UpdateStatus(role string, submissionID int, request *model.RequestSubmissionStatus) (*model.Submission, error) {
+	if _, known := submissionTransitions[request.Status]; !known {
+		return nil, fmt.Errorf("invalid status")
+	}
+
+	submission, err := su.getSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.MovieID != nil {
+		return nil, fmt.Errorf("submission already converted")
+	}
+
+	allowed := false
+	for _, next := range submissionTransitions[submission.Status] {
+		if next == request.Status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("invalid status change")
+	}
+
+	updated, err := su.SubmissionsRepo.UpdateStatus(submissionID, submission.Status, request.Status)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		// Another programmer changed the submission in the meantime
+		return nil, fmt.Errorf("submission changed")
+	}
+
+	return su.GetSubmission(role, submissionID)
+}
+
+// AddNote adds a programmer note to a submission, shared with the filmmaker on request.
+func (su *SubmissionsUseCase) AddNote(role string, authorID, submissionID int, request *model.RequestSubmissionNote) (*model.Submission, error) {
+	body := strings.TrimSpace(request.Body)
+	if body == "" || len(body) > 5000 {
+		return nil, fmt.Errorf("invalid note")
+	}
+
+	if _, err := su.getSubmission(submissionID); err != nil {
+		return nil, err
+	}
+
+	if err := su.SubmissionsRepo.CreateNote(submissionID, authorID, body, request.Shared); err != nil {
+		return nil, err
+	}
+
+	return su.GetSubmission(role, submissionID)
+}
+
+// Convert turns a selected submission into a catalogue movie in its edition and section.
+func (su *SubmissionsUseCase) Convert(role string, submissionID int) (*model.Submission, error) {
+	submission, err := su.getSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.MovieID != nil {
+		return nil, fmt.Errorf("submission already converted")
+	}
+	if submission.Status != model.SubmissionStatusSelected {
+		return nil, fmt.Errorf("submission not selected")
+	}
+
+	movieID, err := su.SubmissionsRepo.Convert(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if movieID == 0 {
+		// The submission was converted or reopened in the meantime
+		return nil, fmt.Errorf("submission changed")
+	}
+
+	return su.GetSubmission(role, submissionID)
+}
+
+// AssignReviewer assigns a reviewer to a submission that is received or in review, moving a received
+// submission into review. The reviewer's role must grant submissions:review, and reviewers cannot be
+// assigned their own film or a film they declared a conflict of interest on.
+func (su *SubmissionsUseCase) AssignReviewer(assignerID, submissionID, reviewerID int) ([]model.SubmissionReview, error) {
+	submission, err := su.getSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.Status != model.SubmissionStatusReceived && submission.Status != model.SubmissionStatusInReview {
+		return nil, fmt.Errorf("submission not in review")
+	}
+
+	user, err := su.UsersRepo.GetUserByID(reviewerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+
+	reviewer, err := su.RolesRepo.RoleHasPermission(user.Role, "submissions:review")
+	if err != nil {
+		return nil, err
+	}
+	if !reviewer {
+		return nil, fmt.Errorf("user is not a reviewer")
+	}
+
+	if reviewerID == submission.UserID {
+		return nil, fmt.Errorf("reviewer has conflict")
+	}
+	conflict, err := su.SubmissionsRepo.HasConflict(submissionID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if conflict {
+		return nil, fmt.Errorf("reviewer has conflict")
+	}
+
+	if err := su.SubmissionsRepo.AssignReviewer(submissionID, reviewerID, assignerID); err != nil {
+		return nil, err
+	}
+
+	if submission.Status == model.SubmissionStatusReceived {
+		// Another programmer may have moved the submission already; either way it left "received"
+		if _, err := su.SubmissionsRepo.UpdateStatus(submissionID, model.SubmissionStatusReceived, model.SubmissionStatusInReview); err != nil {
+			return nil, err
+		}
+	}
+
+	return su.SubmissionsRepo.ListReviews(submissionID)
+}
+
+// UnassignReviewer removes a reviewer and their review from a submission.
+func (su *SubmissionsUseCase) UnassignReviewer(submissionID, reviewerID int) error {
+	if _, err := su.getSubmission(submissionID); err != nil {
+		return err
+	}
+
+	removed, err := su.SubmissionsRepo.UnassignReviewer(submissionID, reviewerID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("reviewer not assigned")
+	}
+
+	return nil
+}
+
+// ListAssignedSubmissions returns a reviewer's queue: the submissions assigned to them with their own
+// review. Filmmaker identities are hidden in blind editions unless the role leads the review.
+func (su *SubmissionsUseCase) ListAssignedSubmissions(reviewerID int, role string) ([]model.Submission, error) {
+	submissions, err := su.SubmissionsRepo.ListAssignedSubmissions(reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := su.SubmissionsRepo.ListReviewerReviews(reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewsBySubmission := make(map[int]model.SubmissionReview, len(reviews))
+	for _, review := range reviews {
+		reviewsBySubmission[review.SubmissionID] = review
+	}
+	for i := range submissions {
+		if review, ok := reviewsBySubmission[submissions[i].ID]; ok {
+			submissions[i].MyReview = &review
+		}
+	}
+
+	if err := su.hideSubmittersFrom(role, submissions); err != nil {
+		return nil, err
+	}
+
+	return submissions, nil
+}
+
+// SaveReview stores a reviewer's score from 1 to 10 and comment on a submission assigned to them
+// while it is in review. Reviewing again replaces the previous review.
+func (su *SubmissionsUseCase) SaveReview(reviewerID, submissionID int, request *model.RequestSubmissionReview) (*model.SubmissionReview, error) {
+	comment := strings.TrimSpace(request.Comment)
+	if request.Score < 1 || request.Score > 10 || len(comment) > 5000 {
+		return nil, fmt.Errorf("invalid review")
+	}
+
+	submission, err := su.getSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := su.SubmissionsRepo.GetReview(submissionID, reviewerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("submission not assigned")
+		}
+		return nil, err
+	}
+
+	if submission.Status != model.SubmissionStatusInReview {
+		return nil, fmt.Errorf("submission not in review")
+	}
+
+	if err := su.SubmissionsRepo.SaveReview(submissionID, reviewerID, request.Score, comment); err != nil {
+		return nil, err
+	}
+
+	return su.SubmissionsRepo.GetReview(submissionID, reviewerID)
+}
+
+// DeclareConflict records a reviewer's conflict of interest on a submission and withdraws them from
+// its review. Reviewers can declare a conflict before being assigned.
+func (su *SubmissionsUseCase) DeclareConflict(reviewerID, submissionID int, request *model.RequestSubmissionConflict) error {
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" || len(reason) > 1000 {
+		return fmt.Errorf("invalid conflict")
+	}
+
+	if _, err := su.getSubmission(submissionID); err != nil {
+		return err
+	}
+
+	return su.SubmissionsRepo.DeclareConflict(submissionID, reviewerID, reason)
+}
+
+// GetDashboard summarizes the review progress of an edition per programme section. A zero editionID
+// selects the current edition.
+func (su *SubmissionsUseCase) GetDashboard(editionID int) (*model.SubmissionDashboard, error) {
+	var edition *model.FestivalEdition
+	var err error
+	if editionID == 0 {
+		edition, err = su.FestivalRepo.GetCurrentEdition()
+	} else {
+		edition, err = su.FestivalRepo.GetEdition(editionID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("edition not found")
+		}
+		return nil, err
+	}
+
+	sections, err := su.SubmissionsRepo.GetReviewProgress(edition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sections {
+		if sections[i].AverageScore != nil {
+			average := math.Round(*sections[i].AverageScore*100) / 100
+			sections[i].AverageScore = &average
+		}
+	}
+
+	return &model.SubmissionDashboard{EditionID: edition.ID, BlindReview: edition.BlindReview, Sections: sections}, nil
+}
+
+// isReviewLead checks whether a role leads submission review, which lets it assign reviewers and see
+// filmmaker identities in blind editions.
+func (su *SubmissionsUseCase) isReviewLead(role string) (bool, error) {
+	return su.RolesRepo.RoleHasPermission(role, "submissions:assign")
+}
+
+// hideSubmittersFrom hides filmmaker identities in blind editions unless the role leads the review.
+func (su *SubmissionsUseCase) hideSubmittersFrom(role string, submissions []model.Submission) error {
+	lead, err := su.isReviewLead(role)
+	if err != nil || lead {
+		return err
+	}
+
+	return su.hideSubmitters(submissions)
+}
+
+// hideSubmitters removes filmmaker identities from the submissions of blind editions.
+func (su *SubmissionsUseCase) hideSubmitters(submissions []model.Submission) error {
+	blindEditions := map[int]bool{}
+	for i := range submissions {
+		blind, known := blindEditions[submissions[i].EditionID]
+		if !known {
+			edition, err := su.FestivalRepo.GetEdition(submissions[i].EditionID)
+			if err != nil {
+				return err
+			}
+			blind = edition.BlindReview
+			blindEditions[submissions[i].EditionID] = blind
+		}
+
+		if blind {
+			submissions[i].UserID = 0
+			submissions[i].SubmitterName = ""
+			submissions[i].Artist = ""
+		}
+	}
+
+	return nil
+}
+
+// getSubmission loads a submission, mapping a missing row to "submission not found".
+func (su *SubmissionsUseCase) getSubmission(submissionID int) (*model.Submission, error) {
+	submission, err := su.SubmissionsRepo.GetSubmission(submissionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("submission not found")
+		}
+		return nil, err
+	}
+
+	return submission, nil
+}
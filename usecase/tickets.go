@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"movies/repository"
+	"movies/utils"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TicketsUnavailableError is returned when a screening has fewer seats left than requested.
+type TicketsUnavailableError struct {
+	Available int // Seats still available
+}
+
+func (e *TicketsUnavailableError) Error() string {
+	return "not enough seats"
+}
+
+type TicketsUseCase struct {
+	TicketsRepo    *repository.TicketsRepo
+	ScreeningsRepo *repository.ScreeningsRepo
+	AttendanceHub  *AttendanceHub
+}
+
+func NewTicketsUseCase(TicketsRepo *repository.TicketsRepo, ScreeningsRepo *repository.ScreeningsRepo) *TicketsUseCase {
+	return &TicketsUseCase{TicketsRepo: TicketsRepo, ScreeningsRepo: ScreeningsRepo, AttendanceHub: NewAttendanceHub()}
+}
+
+// ReserveSeats holds seats for a screening for TICKET_HOLD_MINUTES (default 10). Up to
+// TICKET_MAX_SEATS (default 10) seats can be held at once, and only before the screening starts.
+func (tu *TicketsUseCase) ReserveSeats(userID, screeningID, seats int) (*model.Ticket, error) {
+	if seats <= 0 || seats > utils.GetEnvInt("TICKET_MAX_SEATS", 10) {
+		return nil, fmt.Errorf("invalid seats")
+	}
+
+	screening, err := tu.ScreeningsRepo.GetScreening(screeningID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("screening not found")
+		}
+		return nil, err
+	}
+	if !time.Now().Before(screening.StartsAt) {
+		return nil, fmt.Errorf("screening started")
+	}
+
+	code, err := utils.GenerateToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ticket code: %w", err)
+	}
+
+	ticket := &model.Ticket{
+		ScreeningID: screeningID,
+		UserID:      userID,
+		Seats:       seats,
+		Code:        code,
+	}
+
+	reserved, available, err := tu.TicketsRepo.ReserveSeats(ticket, utils.GetEnvInt("TICKET_HOLD_MINUTES", 10))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("screening not found")
+		}
+		return nil, err
+	}
+	if !reserved {
+		return nil, &TicketsUnavailableError{Available: available}
+	}
+
+	return tu.GetTicket(userID, ticket.ID)
+}
+
+// ListTickets returns the tickets of a user.
+func (tu *TicketsUseCase) ListTickets(userID int) ([]model.Ticket, error) {
+	tickets, err := tu.TicketsRepo.ListUserTickets(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tickets {
+		withQRPayload(&tickets[i])
+	}
+
+	return tickets, nil
+}
+
+// GetTicket returns a ticket of a user. Confirmed tickets include the QR payload for check-in.
+func (tu *TicketsUseCase) GetTicket(userID, ticketID int) (*model.Ticket, error) {
+	ticket, err := tu.getOwnTicket(userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	withQRPayload(ticket)
+	return ticket, nil
+}
+
+// ConfirmTicket confirms a held ticket before its hold runs out.
+func (tu *TicketsUseCase) ConfirmTicket(userID, ticketID int) (*model.Ticket, error) {
+	ticket, err := tu.getOwnTicket(userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ticket.Status {
+	case model.TicketStatusConfirmed:
+		return nil, fmt.Errorf("ticket already confirmed")
+	case model.TicketStatusExpired:
+		return nil, fmt.Errorf("hold expired")
+	case model.TicketStatusCancelled:
+		return nil, fmt.Errorf("ticket cancelled")
+	}
+
+	confirmed, err := tu.TicketsRepo.ConfirmTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		// The hold ran out between loading and confirming the ticket
+		return nil, fmt.Errorf("hold expired")
+	}
+
+	return tu.GetTicket(userID, ticketID)
+}
+
+// CancelTicket cancels a held or confirmed ticket before the screening starts, releasing its seats.
+func (tu *TicketsUseCase) CancelTicket(userID, ticketID int) error {
+	ticket, err := tu.getOwnTicket(userID, ticketID)
+	if err != nil {
+		return err
+	}
+
+	if ticket.Status != model.TicketStatusHeld && ticket.Status != model.TicketStatusConfirmed || ticket.CheckedInAt != nil {
+		return fmt.Errorf("ticket not active")
+	}
+	if !time.Now().Before(ticket.StartsAt) {
+		return fmt.Errorf("screening started")
+	}
+
+	cancelled, err := tu.TicketsRepo.CancelTicket(ticketID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return fmt.Errorf("ticket not active")
+	}
+
+	return nil
+}
+
+// getOwnTicket loads a ticket, reporting tickets of other users as missing.
+func (tu *TicketsUseCase) getOwnTicket(userID, ticketID int) (*model.Ticket, error) {
+	ticket, err := tu.TicketsRepo.GetTicket(ticketID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ticket not found")
+		}
+		return nil, err
+	}
+	if ticket.UserID != userID {
+		return nil, fmt.Errorf("ticket not found")
+	}
+
+	return ticket, nil
+}
+
+// withQRPayload sets the signed check-in payload of a confirmed ticket.
+func withQRPayload(ticket *model.Ticket) {
+	if ticket.Status == model.TicketStatusConfirmed {
+		ticket.QRPayload = SignTicketCode(ticket.ID, ticket.Code)
+	}
+}
+
+// SignTicketCode returns the payload encoded in a ticket's QR code: "<ticket id>.<code>" followed by its signature.
+func SignTicketCode(ticketID int, code string) string {
+	return utils.SignValue(ticketSecret(), strconv.Itoa(ticketID)+"."+code)
+}
+
+// VerifyTicketCode checks the signature of a QR payload and returns the ticket ID and code it carries.
+func VerifyTicketCode(payload string) (int, string, bool) {
+	value, ok := utils.VerifySignedValue(ticketSecret(), strings.TrimSpace(payload))
+	if !ok {
+		return 0, "", false
+	}
+
+	id, code, found := strings.Cut(value, ".")
+	ticketID, err := strconv.Atoi(id)
+	if !found || err != nil || code == "" {
+		return 0, "", false
+	}
+
+	return ticketID, code, true
+}
+
+// ticketSecret returns the key used to sign ticket codes: TICKET_SECRET, falling back to VISITOR_SECRET and JWT_SECRET.
+func ticketSecret() []byte {
+	for _, key := range []string{"TICKET_SECRET", "VISITOR_SECRET", "JWT_SECRET"} {
+		if secret := os.Getenv(key); secret != "" {
+			return []byte(secret)
+		}
+	}
+	return nil
+}
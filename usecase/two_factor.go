@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"movies/model"
+	"movies/utils"
+	"os"
+	"strings"
+	"time"
+)
+
+// recoveryCodeCount is the number of recovery codes issued when two-factor authentication is enabled.
+const recoveryCodeCount = 10
+
+// SetupTwoFactor starts TOTP enrolment by storing a pending secret and returning its provisioning URI.
+func (pu *UsersUseCase) SetupTwoFactor(userID int) (*model.TwoFactorSetup, error) {
+	user, err := pu.getUserForTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, fmt.Errorf("two-factor already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	if err := pu.UsersRepo.SetPendingTOTPSecret(userID, secret); err != nil {
+		return nil, err
+	}
+
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = "Movie Festival"
+	}
+
+	return &model.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(issuer, user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor confirms the pending secret with a code from the authenticator app and returns
+// the recovery codes, which are shown only once. Existing sessions are logged out because they
+// were established without the second factor.
+func (pu *UsersUseCase) EnableTwoFactor(userID int, code string) ([]string, error) {
+	user, err := pu.getUserForTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, fmt.Errorf("two-factor already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, fmt.Errorf("two-factor setup required")
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("invalid code")
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pu.UsersRepo.EnableTOTP(userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	if err := pu.TokensRepo.RevokeUserRefreshTokens(userID); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off after checking the password and a current code.
+func (pu *UsersUseCase) DisableTwoFactor(userID int, password, code string) error {
+	if err := pu.checkPassword(userID, password); err != nil {
+		return err
+	}
+
+	user, err := pu.getUserForTwoFactor(userID)
+	if err != nil {
+		return err
+	}
+	if user.TOTPEnabledAt == nil {
+		return fmt.Errorf("two-factor not enabled")
+	}
+
+	if err := pu.verifySecondFactor(user, code); err != nil {
+		return err
+	}
+
+	return pu.UsersRepo.DisableTOTP(userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after checking a current code.
+func (pu *UsersUseCase) RegenerateRecoveryCodes(userID int, code string) ([]string, error) {
+	user, err := pu.getUserForTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt == nil {
+		return nil, fmt.Errorf("two-factor not enabled")
+	}
+
+	if err := pu.verifySecondFactor(user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pu.UsersRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// StartTwoFactorLogin issues the short-lived token that lets a user who passed the password step
+// submit their second factor, configured by TWO_FACTOR_LOGIN_TTL_MINUTES (default 5).
+func (pu *UsersUseCase) StartTwoFactorLogin(userID int) (string, time.Duration, error) {
+	ttl := time.Duration(utils.GetEnvInt("TWO_FACTOR_LOGIN_TTL_MINUTES", 5)) * time.Minute
+
+	token, err := pu.createUserToken(userID, model.TokenPurposeTwoFactorLogin, ttl)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, ttl, nil
+}
+
+// CompleteTwoFactorLogin checks the second factor of a login. The login token works once, and a wrong
+// code counts as a failed login for the account and the IP, so guesses lock out like wrong passwords.
+func (pu *UsersUseCase) CompleteTwoFactorLogin(mfaToken, code, ip string) (*model.Users, error) {
+	userID, err := pu.TokensRepo.ConsumeUserToken(utils.HashToken(mfaToken), model.TokenPurposeTwoFactorLogin)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invalid mfa token")
+		}
+		return nil, err
+	}
+
+	user, err := pu.getUserForTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DisabledAt != nil {
+		return nil, fmt.Errorf("account disabled")
+	}
+	if user.TOTPEnabledAt == nil {
+		return nil, fmt.Errorf("two-factor not enabled")
+	}
+
+	// Tokens issued before a lockout must not keep guessing
+	account := strings.ToLower(user.Email)
+	if err := pu.checkLoginLocks(account, ip); err != nil {
+		return nil, err
+	}
+
+	if err := pu.verifySecondFactor(user, code); err != nil {
+		if strings.Contains(err.Error(), "invalid code") {
+			if failure := pu.recordLoginFailure(account, ip); !errors.Is(failure, ErrInvalidCredentials) {
+				return nil, failure
+			}
+		}
+		return nil, err
+	}
+
+	if err := pu.LoginThrottleRepo.Clear(model.LoginScopeAccount, account); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// verifySecondFactor accepts a TOTP code that was not used before, or an unused recovery code.
+func (pu *UsersUseCase) verifySecondFactor(user *model.Users, code string) error {
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		fresh, err := pu.UsersRepo.UseTOTPStep(user.Id, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return fmt.Errorf("invalid code")
+		}
+		return nil
+	}
+
+	used, err := pu.UsersRepo.UseRecoveryCode(user.Id, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return fmt.Errorf("invalid code")
+	}
+
+	return nil
+}
+
+// getUserForTwoFactor loads a user, mapping a missing row to "user not found".
+func (pu *UsersUseCase) getUserForTwoFactor(userID int) (*model.Users, error) {
+	user, err := pu.UsersRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// newRecoveryCodes generates recovery codes formatted as "xxxxx-xxxxx" together with their hashes.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateToken(5)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, utils.HashToken(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode strips the separator and spaces users may type with a recovery code.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
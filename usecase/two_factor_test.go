@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"database/sql/driver"
+	"errors"
+	"movies/internal/fakedb"
+	"movies/model"
+	"movies/repository"
+	"movies/utils"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// twoFactorFixture is an in-memory account with two-factor authentication, password "secret" and the
+// recovery codes "abcde-12345" and "fghij-67890".
+type twoFactorFixture struct {
+	usersUseCase  *UsersUseCase
+	failures      map[string]int  // Failed logins by scope and subject
+	lockedSeconds map[string]int  // Lockout lengths by scope and subject
+	recoveryCodes map[string]bool // Recovery code hashes, true once used
+}
+
+// fakeToken is one user_tokens row of the fake database.
+type fakeToken struct {
+	userID    int
+	purpose   string
+	tokenHash string
+	used      bool
+}
+
+func newTwoFactorFixture(t *testing.T) *twoFactorFixture {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := &twoFactorFixture{
+		failures:      map[string]int{},
+		lockedSeconds: map[string]int{},
+		recoveryCodes: map[string]bool{utils.HashToken("abcde12345"): false, utils.HashToken("fghij67890"): false},
+	}
+	var tokens []*fakeToken
+
+	user := []driver.Value{int64(1), "viewer@example.com", string(hash), "", "user", "", "",
+		"2026-01-01 00:00:00", nil, "2026-01-01 00:00:00", "JBSWY3DPEHPK3PXP", "2026-01-01 00:00:00"}
+
+	db := fakedb.Open(func(stmt *fakedb.Statement) (*fakedb.Result, error) {
+		subject := stmt.String("scope") + "/" + stmt.String("subject")
+
+		switch stmt.Table + " " + stmt.Verb {
+		case "users SELECT":
+			return &fakedb.Result{Rows: [][]driver.Value{user}}, nil
+		case "login_throttles SELECT":
+			if stmt.Mentions("failed_count") {
+				return &fakedb.Result{Rows: [][]driver.Value{{int64(fixture.failures[subject])}}}, nil
+			}
+			if seconds := fixture.lockedSeconds[subject]; seconds > 0 {
+				return &fakedb.Result{Rows: [][]driver.Value{{int64(seconds)}}}, nil
+			}
+			return nil, nil
+		case "login_throttles INSERT":
+			fixture.failures[subject]++
+			return &fakedb.Result{RowsAffected: 1}, nil
+		case "login_throttles UPDATE":
+			fixture.lockedSeconds[subject] = stmt.Int("interval")
+			return &fakedb.Result{RowsAffected: 1}, nil
+		case "login_throttles DELETE":
+			delete(fixture.failures, subject)
+			delete(fixture.lockedSeconds, subject)
+			return &fakedb.Result{RowsAffected: 1}, nil
+		case "user_tokens INSERT":
+			tokens = append(tokens, &fakeToken{userID: stmt.Int("user_id"), purpose: stmt.String("purpose"), tokenHash: stmt.String("token_hash")})
+			return &fakedb.Result{RowsAffected: 1}, nil
+		case "user_tokens SELECT":
+			for i, token := range tokens {
+				if token.tokenHash == stmt.String("token_hash") && token.purpose == stmt.String("purpose") && !token.used {
+					return &fakedb.Result{Rows: [][]driver.Value{{int64(i + 1), int64(token.userID)}}}, nil
+				}
+			}
+			return nil, nil
+		case "user_tokens UPDATE":
+			// Tokens are used up by ID, or all at once by user and purpose when a new one is issued
+			for i, token := range tokens {
+				if stmt.Int("id") == i+1 || (stmt.Args["id"] == nil && token.userID == stmt.Int("user_id") && token.purpose == stmt.String("purpose")) {
+					token.used = true
+				}
+			}
+			return &fakedb.Result{RowsAffected: 1}, nil
+		case "recovery_codes UPDATE":
+			if used, ok := fixture.recoveryCodes[stmt.String("code_hash")]; ok && !used && stmt.Int("user_id") == 1 {
+				fixture.recoveryCodes[stmt.String("code_hash")] = true
+				return &fakedb.Result{RowsAffected: 1}, nil
+			}
+			return nil, nil
+		}
+
+		t.Errorf("unexpected %s on %s", stmt.Verb, stmt.Table)
+		return nil, errors.New("unexpected statement")
+	})
+
+	fixture.usersUseCase = NewUsersUseCase(repository.NewUsersRepo(db), repository.NewTokensRepo(db), repository.NewRolesRepo(db),
+		repository.NewLoginThrottleRepo(db), nil, nil)
+	return fixture
+}
+
+// startLogin passes the password step and returns the token for the second factor.
+func (f *twoFactorFixture) startLogin(t *testing.T) (string, error) {
+	user, err := f.usersUseCase.Login("viewer@example.com", "secret", "203.0.113.7")
+	if err != nil {
+		return "", err
+	}
+
+	mfaToken, _, err := f.usersUseCase.StartTwoFactorLogin(user.Id)
+	if err != nil {
+		t.Fatalf("failed to start two-factor login: %v", err)
+	}
+	return mfaToken, nil
+}
+
+func TestWrongSecondFactorCodesLockOutTheAccount(t *testing.T) {
+	t.Setenv("LOGIN_MAX_ATTEMPTS", "3")
+	fixture := newTwoFactorFixture(t)
+
+	var locked *LoginLockedError
+	for attempt := 1; attempt <= 3; attempt++ {
+		// The correct password must not reset the failures of the second factor
+		mfaToken, err := fixture.startLogin(t)
+		if err != nil {
+			t.Fatalf("attempt %d: login failed: %v", attempt, err)
+		}
+
+		_, err = fixture.usersUseCase.CompleteTwoFactorLogin(mfaToken, "abcdef", "203.0.113.7")
+		if attempt < 3 && (err == nil || !strings.Contains(err.Error(), "invalid code")) {
+			t.Fatalf("attempt %d: expected invalid code, got %v", attempt, err)
+		}
+		if attempt == 3 && !errors.As(err, &locked) {
+			t.Fatalf("attempt %d: expected LoginLockedError, got %v", attempt, err)
+		}
+	}
+
+	if _, err := fixture.startLogin(t); !errors.As(err, &locked) {
+		t.Fatalf("expected the password step to be locked, got %v", err)
+	}
+}
+
+func TestLockedAccountRefusesPendingSecondFactor(t *testing.T) {
+	t.Setenv("LOGIN_MAX_ATTEMPTS", "3")
+	fixture := newTwoFactorFixture(t)
+
+	mfaToken, err := fixture.startLogin(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wrong passwords lock the account while the second step is pending
+	for attempt := 1; attempt <= 3; attempt++ {
+		if _, err := fixture.usersUseCase.Login("viewer@example.com", "wrong", "203.0.113.7"); err == nil {
+			t.Fatalf("attempt %d: expected the wrong password to fail", attempt)
+		}
+	}
+
+	var locked *LoginLockedError
+	if _, err := fixture.usersUseCase.CompleteTwoFactorLogin(mfaToken, "abcde-12345", "203.0.113.7"); !errors.As(err, &locked) {
+		t.Fatalf("expected the second factor to be locked, got %v", err)
+	}
+	if fixture.recoveryCodes[utils.HashToken("abcde12345")] {
+		t.Fatal("expected the recovery code to stay unused while the account is locked")
+	}
+}
+
+func TestRecoveryCodeCompletesLoginOnce(t *testing.T) {
+	fixture := newTwoFactorFixture(t)
+	account := model.LoginScopeAccount + "/viewer@example.com"
+
+	mfaToken, err := fixture.startLogin(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fixture.usersUseCase.CompleteTwoFactorLogin(mfaToken, "abcdef", "203.0.113.7"); err == nil {
+		t.Fatal("expected a wrong code to fail")
+	}
+	if fixture.failures[account] != 1 {
+		t.Fatalf("expected one failed login for the account, got %d", fixture.failures[account])
+	}
+
+	mfaToken, err = fixture.startLogin(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := fixture.usersUseCase.CompleteTwoFactorLogin(mfaToken, "ABCDE-12345", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("expected the recovery code to complete the login, got %v", err)
+	}
+	if user.Id != 1 {
+		t.Fatalf("expected user 1, got %d", user.Id)
+	}
+	if !fixture.recoveryCodes[utils.HashToken("abcde12345")] || fixture.recoveryCodes[utils.HashToken("fghij67890")] {
+		t.Fatalf("expected only the entered recovery code to be used, got %v", fixture.recoveryCodes)
+	}
+	if fixture.failures[account] != 0 {
+		t.Fatalf("expected the completed login to reset the account failures, got %d", fixture.failures[account])
+	}
+
+	mfaToken, err = fixture.startLogin(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fixture.usersUseCase.CompleteTwoFactorLogin(mfaToken, "abcde-12345", "203.0.113.7"); err == nil || !strings.Contains(err.Error(), "invalid code") {
+		t.Fatalf("expected a used recovery code to be refused, got %v", err)
+	}
+}
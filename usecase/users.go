@@ -1,17 +1,46 @@
 package usecase
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"log"
+	"movies/mailer"
 	"movies/model"
 	"movies/repository"
+	"movies/utils"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidCredentials is returned for both unknown emails and wrong passwords so logins cannot enumerate accounts.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// LoginLockedError is returned while an account or client IP is locked out after too many failed logins.
+type LoginLockedError struct {
+	RetryAfter time.Duration // Time left until the lockout ends
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("login locked for %s", e.RetryAfter)
+}
+
 type UsersUseCase struct {
-	UsersRepo *repository.UsersRepo
+	UsersRepo         *repository.UsersRepo
+	TokensRepo        *repository.TokensRepo
+	RolesRepo         *repository.RolesRepo
+	LoginThrottleRepo *repository.LoginThrottleRepo
+	AbuseUseCase      *AbuseUseCase
+	Mailer            mailer.Mailer
 }
 
-func NewUsersUseCase(UsersRepo *repository.UsersRepo) *UsersUseCase {
-	return &UsersUseCase{UsersRepo: UsersRepo}
+func NewUsersUseCase(UsersRepo *repository.UsersRepo, TokensRepo *repository.TokensRepo, RolesRepo *repository.RolesRepo, LoginThrottleRepo *repository.LoginThrottleRepo, AbuseUseCase *AbuseUseCase, Mailer mailer.Mailer) *UsersUseCase {
+	return &UsersUseCase{UsersRepo: UsersRepo, TokensRepo: TokensRepo, RolesRepo: RolesRepo, LoginThrottleRepo: LoginThrottleRepo, AbuseUseCase: AbuseUseCase, Mailer: Mailer}
 }
 
 // Create handles user creation logic.
@@ -26,10 +55,623 @@ func (pu *UsersUseCase) Create(user *model.Users) (*model.Users, error) {
 	}
 
 	// Proceed to create the user if email doesn't exist
-	return pu.UsersRepo.Create(user)
+	created, err := pu.UsersRepo.Create(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// Score the registration so account bursts from one address are flagged
+	pu.AbuseUseCase.ScoreRegistration(created)
+
+	// Ask the user to confirm their address; a failed email does not undo the registration
+	if err := pu.SendVerificationEmail(created); err != nil {
+		log.Println("ERR send verification email: ", err)
+	}
+
+	return created, nil
+}
+
+// SendVerificationEmail emails the user a single-use token confirming their address.
+func (pu *UsersUseCase) SendVerificationEmail(user *model.Users) error {
+	ttl := time.Duration(utils.GetEnvInt("EMAIL_VERIFICATION_TTL_HOURS", 24)) * time.Hour
+	token, err := pu.createUserToken(user.Id, model.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Welcome to the movie festival!\n\n"+
+		"Confirm your email address by sending this token to POST /api/v1/user/verify:\n\n%s\n\n"+
+		"Or open %s/verify-email?token=%s\n\nThe token expires in %s.", token, appBaseURL(), token, ttl)
+
+	return pu.Mailer.Send(user.Email, "Confirm your email address", body)
+}
+
+// VerifyEmail confirms the email address belonging to a verification token.
+func (pu *UsersUseCase) VerifyEmail(token string) error {
+	userID, err := pu.TokensRepo.ConsumeUserToken(utils.HashToken(token), model.TokenPurposeEmailVerification)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("invalid token")
+		}
+		return err
+	}
+
+	return pu.UsersRepo.MarkEmailVerified(userID)
+}
+
+// ForgotPassword emails a password reset token if the email belongs to an active account.
+// Unknown emails are ignored silently so the endpoint does not reveal which accounts exist.
+func (pu *UsersUseCase) ForgotPassword(email string) error {
+	user, err := pu.UsersRepo.GetUser(&model.Users{Email: email})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if user.DisabledAt != nil {
+		return nil
+	}
+
+	ttl := time.Duration(utils.GetEnvInt("PASSWORD_RESET_TTL_MINUTES", 60)) * time.Minute
+	token, err := pu.createUserToken(user.Id, model.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("A password reset was requested for your account.\n\n"+
+		"Send this token with your new password to POST /api/v1/user/password/reset:\n\n%s\n\n"+
+		"Or open %s/reset-password?token=%s\n\nThe token expires in %s. "+
+		"If you did not request a reset, ignore this email.", token, appBaseURL(), token, ttl)
+
+	return pu.Mailer.Send(user.Email, "Reset your password", body)
+}
+
+// ResetPassword sets a new password with a reset token and signs the user out everywhere.
+func (pu *UsersUseCase) ResetPassword(token, password string) error {
+	userID, err := pu.TokensRepo.ConsumeUserToken(utils.HashToken(token), model.TokenPurposePasswordReset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("invalid token")
+		}
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := pu.UsersRepo.UpdatePassword(userID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	// Receiving the reset email proves ownership of the address
+	if err := pu.UsersRepo.MarkEmailVerified(userID); err != nil {
+		return err
+	}
+
+	return pu.TokensRepo.RevokeUserRefreshTokens(userID)
+}
+
+// createUserToken stores a new single-use token for the user and returns its raw value.
+func (pu *UsersUseCase) createUserToken(userID int, purpose string, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	if err := pu.TokensRepo.CreateUserToken(userID, purpose, utils.HashToken(token), time.Now().Add(ttl)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// appBaseURL returns the public URL of the frontend used in email links.
+func appBaseURL() string {
+	if baseURL := os.Getenv("APP_BASE_URL"); baseURL != "" {
+		return strings.TrimRight(baseURL, "/")
+	}
+	return "http://localhost:9191"
+}
+
+// Login checks an email and password while enforcing per-account and per-IP lockouts.
+// Unknown emails and wrong passwords fail identically, including the time spent hashing.
+func (pu *UsersUseCase) Login(email, password, ip string) (*model.Users, error) {
+	account := strings.ToLower(strings.TrimSpace(email))
+
+	// Refuse locked subjects before touching the password
+	if err := pu.checkLoginLocks(account, ip); err != nil {
+		return nil, err
+	}
+
+	user, err := pu.UsersRepo.GetUser(&model.Users{Email: account})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// Compare against a dummy hash for unknown emails so both failures take as long
+	passwordHash := dummyPasswordHash()
+	if user != nil {
+		passwordHash = []byte(user.Password)
+	}
+	if bcrypt.CompareHashAndPassword(passwordHash, []byte(password)) != nil || user == nil {
+		return nil, pu.recordLoginFailure(account, ip)
+	}
+
+	// A successful login resets the account counter; the IP counter only decays so one valid account cannot reset it.
+	// With two-factor enabled the counter is only reset once the second factor is checked, see CompleteTwoFactorLogin.
+	if user.TOTPEnabledAt == nil {
+		if err := pu.LoginThrottleRepo.Clear(model.LoginScopeAccount, account); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.DisabledAt != nil {
+		return nil, fmt.Errorf("account disabled")
+	}
+
+	return user, nil
+}
+
+// checkLoginLocks returns a LoginLockedError if the account or the IP is locked out.
+func (pu *UsersUseCase) checkLoginLocks(account, ip string) error {
+	for _, subject := range []struct{ scope, value string }{{model.LoginScopeIP, ip}, {model.LoginScopeAccount, account}} {
+		remaining, err := pu.LoginThrottleRepo.GetLockRemaining(subject.scope, subject.value)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return &LoginLockedError{RetryAfter: remaining}
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login for the account and the IP, locking whichever reached its limit.
+// Each failure past the limit doubles the lockout, up to LOGIN_MAX_LOCKOUT_MINUTES.
+func (pu *UsersUseCase) recordLoginFailure(account, ip string) error {
+	window := utils.GetEnvInt("LOGIN_ATTEMPT_WINDOW_MINUTES", 60)
+	lockout := time.Duration(utils.GetEnvInt("LOGIN_LOCKOUT_MINUTES", 5)) * time.Minute
+	maxLockout := time.Duration(utils.GetEnvInt("LOGIN_MAX_LOCKOUT_MINUTES", 1440)) * time.Minute
+
+	var locked time.Duration
+	subjects := []struct {
+		scope, value string
+		limit        int
+	}{
+		{model.LoginScopeAccount, account, utils.GetEnvInt("LOGIN_MAX_ATTEMPTS", 5)},
+		{model.LoginScopeIP, ip, utils.GetEnvInt("LOGIN_MAX_IP_ATTEMPTS", 20)},
+	}
+	for _, subject := range subjects {
+		failures, err := pu.LoginThrottleRepo.RecordFailure(subject.scope, subject.value, window)
+		if err != nil {
+			return err
+		}
+		if failures < subject.limit {
+			continue
+		}
+
+		duration := lockout
+		for i := subject.limit; i < failures && duration < maxLockout; i++ {
+			duration *= 2
+		}
+		if duration > maxLockout {
+			duration = maxLockout
+		}
+
+		if err := pu.LoginThrottleRepo.Lock(subject.scope, subject.value, duration); err != nil {
+			return err
+		}
+		if duration > locked {
+			locked = duration
+		}
+	}
+
+	if locked > 0 {
+		return &LoginLockedError{RetryAfter: locked}
+	}
+	return ErrInvalidCredentials
+}
+
+// ListLockedLogins retrieves the accounts and client IPs currently locked out.
+func (pu *UsersUseCase) ListLockedLogins() ([]model.LoginThrottle, []model.LoginThrottle, error) {
+	accounts, err := pu.LoginThrottleRepo.ListLocked(model.LoginScopeAccount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ips, err := pu.LoginThrottleRepo.ListLocked(model.LoginScopeIP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return accounts, ips, nil
+}
+
+// UnlockUser lifts the login lockout of a user account.
+func (pu *UsersUseCase) UnlockUser(userID int) error {
+	user, err := pu.UsersRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user not found")
+		}
+		return err
+	}
+
+	return pu.LoginThrottleRepo.Clear(model.LoginScopeAccount, strings.ToLower(user.Email))
+}
+
+// UnlockIP lifts the login lockout of a client IP address.
+func (pu *UsersUseCase) UnlockIP(ip string) error {
+	return pu.LoginThrottleRepo.Clear(model.LoginScopeIP, ip)
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// dummyPasswordHash returns a bcrypt hash used to spend the same time on unknown emails as on real ones.
+func dummyPasswordHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	})
+	return dummyHash
 }
 
 // GetUser retrieves the user from the database.
 func (pu *UsersUseCase) GetUser(user *model.Users) (*model.Users, error) {
 	return pu.UsersRepo.GetUser(user)
 }
+
+// GetProfile retrieves the profile of the given user.
+func (pu *UsersUseCase) GetProfile(userID int) (*model.UserProfile, error) {
+	user, err := pu.UsersRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+
+	return user.Profile(), nil
+}
+
+// UpdateProfile validates and applies a profile update, returning the updated profile.
+func (pu *UsersUseCase) UpdateProfile(userID int, request model.RequestUpdateProfile) (*model.UserProfile, error) {
+	updates := make(map[string]interface{})
+
+	if request.Gender != nil {
+		gender := strings.TrimSpace(*request.Gender)
+		if gender == "" || len(gender) > 25 {
+			return nil, fmt.Errorf("invalid gender")
+		}
+		updates["gender"] = gender
+	}
+	if request.DisplayName != nil {
+		displayName := strings.TrimSpace(*request.DisplayName)
+		if len(displayName) > 100 {
+			return nil, fmt.Errorf("invalid display name")
+		}
+		updates["display_name"] = nullIfEmpty(displayName)
+	}
+	if request.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*request.AvatarURL)
+		if avatarURL != "" && !isHTTPURL(avatarURL) {
+			return nil, fmt.Errorf("invalid avatar url")
+		}
+		updates["avatar_url"] = nullIfEmpty(avatarURL)
+	}
+
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	if err := pu.UsersRepo.UpdateProfile(userID, updates); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+
+	return pu.GetProfile(userID)
+}
+
+// ChangePassword replaces the user's password after checking the current one.
+// Every refresh token is revoked so other devices have to log in again.
+func (pu *UsersUseCase) ChangePassword(userID int, currentPassword, newPassword string) error {
+	if err := pu.checkPassword(userID, currentPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := pu.UsersRepo.UpdatePassword(userID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	return pu.TokensRepo.RevokeUserRefreshTokens(userID)
+}
+
+// DeleteAccount permanently removes the user's account after checking their password.
+// The last active admin cannot delete themselves, so the festival is never left without one.
+func (pu *UsersUseCase) DeleteAccount(userID int, password string) error {
+	if err := pu.checkPassword(userID, password); err != nil {
+		return err
+	}
+
+	user, err := pu.UsersRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == "admin" {
+		admins, err := pu.UsersRepo.CountActiveAdmins()
+		if err != nil {
+			return err
+		}
+		if admins <= 1 {
+			return fmt.Errorf("cannot delete the last admin")
+		}
+	}
+
+	if err := pu.UsersRepo.DeleteUser(userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkPassword compares a password with the stored hash of the user.
+func (pu *UsersUseCase) checkPassword(userID int, password string) error {
+	user, err := pu.UsersRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user not found")
+		}
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return fmt.Errorf("invalid password")
+	}
+
+	return nil
+}
+
+// nullIfEmpty stores empty optional profile fields as NULL.
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// isHTTPURL checks that a value is an absolute http or https URL.
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// ListUsers retrieves user accounts with pagination for admins.
+func (pu *UsersUseCase) ListUsers(page, limit int) ([]model.UserSummary, error) {
+	if page <= 0 || limit <= 0 {
+		return nil, errors.New("invalid page or limit")
+	}
+	return pu.UsersRepo.ListUsers(page, limit)
+}
+
+// ListRoles retrieves every role with its permissions.
+func (pu *UsersUseCase) ListRoles() ([]model.Role, error) {
+	return pu.RolesRepo.ListRoles()
+}
+
+// UpdateRole changes the role of a user on behalf of an admin, who cannot change their own role.
+func (pu *UsersUseCase) UpdateRole(actorID, userID int, role string) error {
+	if actorID == userID {
+		return fmt.Errorf("cannot change own account")
+	}
+
+	roleExists, err := pu.RolesRepo.RoleExists(role)
+	if err != nil {
+		return err
+	}
+	if !roleExists {
+		return fmt.Errorf("invalid role")
+	}
+
+	err = pu.UsersRepo.UpdateRole(userID, role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("user not found")
+	}
+	return err
+}
+
+// SetDisabled disables or re-enables a user on behalf of an admin, who cannot disable themselves.
+// Disabling also revokes the user's refresh tokens so no new access tokens can be obtained.
+func (pu *UsersUseCase) SetDisabled(actorID, userID int, disabled bool) error {
+	if actorID == userID {
+		return fmt.Errorf("cannot change own account")
+	}
+
+	err := pu.UsersRepo.SetDisabled(userID, disabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if disabled {
+		return pu.TokensRepo.RevokeUserRefreshTokens(userID)
+	}
+	return nil
+}
+
+// BootstrapAdmin creates the first admin account. It refuses to run once any admin exists.
+func (pu *UsersUseCase) BootstrapAdmin(email, password, gender string) (*model.Users, error) {
+	adminExists, err := pu.UsersRepo.AdminExists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing admins: %w", err)
+	}
+	if adminExists {
+		return nil, fmt.Errorf("an admin account already exists; use the admin endpoints to promote users")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	emailExists, err := pu.UsersRepo.IsEmailExists(email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if emailExists {
+		return nil, fmt.Errorf("email already exists")
+	}
+
+	admin, err := pu.UsersRepo.Create(&model.Users{
+		Email:    email,
+		Password: string(hashedPassword),
+		Gender:   gender,
+		Role:     "admin",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The operator running the command vouches for the address
+	if err := pu.UsersRepo.MarkEmailVerified(admin.Id); err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+// CreateRefreshToken issues a refresh token that starts a new rotation family and returns its raw value.
+func (pu *UsersUseCase) CreateRefreshToken(userID int, twoFactor bool) (string, error) {
+	familyID, err := utils.GenerateToken(16)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token family: %w", err)
+	}
+
+	raw, token, err := newRefreshToken(userID, familyID, twoFactor)
+	if err != nil {
+		return "", err
+	}
+
+	if err := pu.TokensRepo.CreateRefreshToken(token); err != nil {
+		return "", err
+	}
+
+	return raw, nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one in the same family and returns the token owner
+// along with whether the original login passed the two-factor step.
+// Presenting a token that was already rotated is treated as theft: the whole family is revoked.
+func (pu *UsersUseCase) RotateRefreshToken(raw string) (*model.Users, string, bool, error) {
+	current, err := pu.TokensRepo.GetRefreshToken(utils.HashToken(raw))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", false, fmt.Errorf("invalid refresh token")
+		}
+		return nil, "", false, fmt.Errorf("failed to load refresh token: %w", err)
+	}
+
+	// A revoked token coming back means it was copied; cut off every token of the family
+	if current.RevokedAt != nil {
+		if err := pu.TokensRepo.RevokeRefreshTokenFamily(current.FamilyID); err != nil {
+			return nil, "", false, err
+		}
+		return nil, "", false, fmt.Errorf("refresh token reuse detected")
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, "", false, fmt.Errorf("invalid refresh token")
+	}
+
+	user, err := pu.UsersRepo.GetUserByID(current.UserID)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to load user: %w", err)
+	}
+	if user.DisabledAt != nil {
+		return nil, "", false, fmt.Errorf("account disabled")
+	}
+
+	newRaw, replacement, err := newRefreshToken(current.UserID, current.FamilyID, current.TwoFactor)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	err = pu.TokensRepo.RotateRefreshToken(current.ID, replacement)
+	if errors.Is(err, repository.ErrRefreshTokenReused) {
+		if err := pu.TokensRepo.RevokeRefreshTokenFamily(current.FamilyID); err != nil {
+			return nil, "", false, err
+		}
+		return nil, "", false, fmt.Errorf("refresh token reuse detected")
+	}
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	return user, newRaw, current.TwoFactor, nil
+}
+
+// Logout revokes the caller's access token and, when given, the refresh token family it belongs to.
+func (pu *UsersUseCase) Logout(userID int, jti string, expiresAt time.Time, refreshToken string) error {
+	if jti != "" {
+		if err := pu.TokensRepo.RevokeAccessToken(jti, expiresAt); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	token, err := pu.TokensRepo.GetRefreshToken(utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to load refresh token: %w", err)
+	}
+
+	// Only the owner may revoke a refresh token
+	if token.UserID != userID {
+		return nil
+	}
+
+	return pu.TokensRepo.RevokeRefreshTokenFamily(token.FamilyID)
+}
+
+// newRefreshToken generates a raw refresh token and the record storing its hash.
+func newRefreshToken(userID int, familyID string, twoFactor bool) (string, *model.RefreshToken, error) {
+	raw, err := utils.GenerateToken(32)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	ttl := time.Duration(utils.GetEnvInt("REFRESH_TOKEN_TTL_DAYS", 30)) * 24 * time.Hour
+	token := &model.RefreshToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(raw),
+		FamilyID:  familyID,
+		TwoFactor: twoFactor,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	return raw, token, nil
+}
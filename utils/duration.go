@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// durationUnits maps the unit words found in movie durations to their length.
+// Catalogue entries mix English and Indonesian ("2 jam 15 menit").
+var durationUnits = map[string]time.Duration{
+	"h":       time.Hour,
+	"hr":      time.Hour,
+	"hrs":     time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"jam":     time.Hour,
+	"m":       time.Minute,
+	"min":     time.Minute,
+	"mins":    time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"menit":   time.Minute,
+}
+
+// ParseMovieDuration parses the free-form duration stored with a movie, such as "2 jam", "1h 45m",
+// "1:45" or a bare number of minutes ("105").
+func ParseMovieDuration(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	// A bare number is a count of minutes
+	if minutes, err := strconv.Atoi(value); err == nil {
+		if minutes <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	// Clock notation: hours and minutes
+	if hours, minutes, ok := strings.Cut(value, ":"); ok {
+		h, errH := strconv.Atoi(hours)
+		m, errM := strconv.Atoi(minutes)
+		if errH != nil || errM != nil || h < 0 || m < 0 || m >= 60 || h+m == 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+	}
+
+	// Number and unit pairs, with or without a space between them
+	var total time.Duration
+	rest := value
+	for rest != "" {
+		rest = strings.TrimLeftFunc(rest, func(r rune) bool { return unicode.IsSpace(r) || r == ',' })
+		if rest == "" {
+			break
+		}
+
+		end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		amount, _ := strconv.Atoi(rest[:end])
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+
+		end = strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end < 0 {
+			end = len(rest)
+		}
+		unit, ok := durationUnits[rest[:end]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		rest = rest[end:]
+
+		total += time.Duration(amount) * unit
+	}
+
+	if total <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+)
+
+// GetEnvInt reads an integer environment variable, returning fallback when it is unset or invalid.
+func GetEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
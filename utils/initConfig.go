@@ -7,17 +7,27 @@ import (
 	"github.com/joho/godotenv"
 )
 
-// InitEnvironment loads environment variables from .env file
+// InitEnvironment loads environment variables from .env file and checks the secrets the server needs
 func InitEnvironment() {
+	LoadEnvironment()
+
+	// JWT_SECRET signs HS256 tokens; with asymmetric JWT_ALG it is only needed when VISITOR_SECRET is unset
+	alg := os.Getenv("JWT_ALG")
+	if alg == "" || alg == "HS256" {
+		if len(os.Getenv("JWT_SECRET")) == 0 {
+			log.Fatal("JWT_SECRET is not set in the environment variables")
+		}
+	} else if len(os.Getenv("VISITOR_SECRET")) == 0 && len(os.Getenv("JWT_SECRET")) == 0 {
+		log.Fatal("VISITOR_SECRET or JWT_SECRET must be set to sign visitor IDs")
+	}
+}
+
+// LoadEnvironment loads environment variables from .env file without checking them, for commands
+func LoadEnvironment() {
 	// Load .env file
 	err := godotenv.Load()
 
 	if err != nil {
 		log.Fatal("Error loading .env file:", err)
 	}
-
-	// Validate if JWT_SECRET is set
-	if len(os.Getenv("JWT_SECRET")) == 0 {
-		log.Fatal("JWT_SECRET is not set in the environment variables")
-	}
 }
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces sensitive values in logs.
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys lists field and query parameter names whose values must never be logged.
+var sensitiveKeys = map[string]bool{
+	"password":         true,
+	"current_password": true,
+	"new_password":     true,
+	"token":            true,
+	"refresh_token":    true,
+	"access_token":     true,
+	"id_token":         true,
+	"code":             true,
+	"code_verifier":    true,
+	"secret":           true,
+	"authorization":    true,
+}
+
+// IsSensitiveKey reports whether values stored under the given name must be redacted.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+// Redact renders a value for logging with sensitive fields masked.
+// The value is rendered through its JSON form, so fields tagged json:"-" are left out as well.
+func Redact(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("<unloggable %T>", value)
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return string(data)
+	}
+
+	redacted, err := json.Marshal(redactJSON(decoded))
+	if err != nil {
+		return string(data)
+	}
+
+	return string(redacted)
+}
+
+// redactJSON masks sensitive keys in a decoded JSON value, recursing into objects and arrays.
+func redactJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if IsSensitiveKey(key) {
+				typed[key] = redactedValue
+			} else {
+				typed[key] = redactJSON(nested)
+			}
+		}
+		return typed
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = redactJSON(nested)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// RedactURL masks sensitive query parameters of a request path such as "/verify?token=...".
+func RedactURL(path string) string {
+	parsed, err := url.Parse(path)
+	if err != nil || parsed.RawQuery == "" {
+		return path
+	}
+
+	query := parsed.Query()
+	for key := range query {
+		if IsSensitiveKey(key) {
+			query.Set(key, redactedValue)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String()
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// SignValue appends an HMAC-SHA256 signature to value, producing "<value>.<signature>".
+func SignValue(secret []byte, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(value))
+	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	return value + "." + signature
+}
+
+// VerifySignedValue checks a value produced by SignValue and returns the original value if the signature matches.
+func VerifySignedValue(secret []byte, signed string) (string, bool) {
+	index := strings.LastIndex(signed, ".")
+	if index <= 0 {
+		return "", false
+	}
+
+	value := signed[:index]
+	if !hmac.Equal([]byte(SignValue(secret, value)), []byte(signed)) {
+		return "", false
+	}
+
+	return value, true
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// GenerateToken returns a random hex-encoded token built from n random bytes.
+func GenerateToken(n int) (string, error) {
+	buffer := make([]byte, n)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buffer), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 hash of a token, used to store tokens without keeping their raw value.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}